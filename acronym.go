@@ -0,0 +1,71 @@
+// acronym.go - encode acronyms as they are spelled out.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+// letterNames are the English names of the letters A-Z.
+var letterNames = [26]string{"ay", "bee", "see", "dee", "ee", "eff", "jee",
+	"aitch", "eye", "jay", "kay", "ell", "em", "en", "oh", "pee", "cue",
+	"ar", "ess", "tee", "you", "vee", "double you", "ex", "why", "zee"}
+
+// knownAcronyms are common acronyms that have vowels, so isAcronym would
+// otherwise read them as words.
+var knownAcronyms = map[string]bool{
+	"AI": true, "AOL": true, "API": true, "ASAP": true, "ATM": true,
+	"BMI": true, "CEO": true, "CFO": true, "CIA": true, "CIO": true,
+	"CPU": true, "CTO": true, "DIY": true, "DOA": true, "DOB": true,
+	"EU": true, "FAQ": true, "FBI": true, "FYI": true, "GDP": true,
+	"GOP": true, "GPU": true, "GUI": true, "HIV": true, "IBM": true,
+	"ID": true, "IEEE": true, "IOU": true, "IP": true, "IPO": true,
+	"IQ": true, "IRA": true, "IRS": true, "ISBN": true, "ISO": true,
+	"IT": true, "MBA": true, "MIT": true, "NBA": true, "NYC": true,
+	"NY": true, "OS": true, "PDA": true, "PIN": true, "RSVP": true,
+	"SUV": true, "UAE": true, "UCLA": true, "UFO": true, "UI": true,
+	"UK": true, "UN": true, "UPS": true, "URL": true, "USA": true,
+	"USB": true, "VIP": true,
+}
+
+// isAcronym returns true if word is two or more capital letters A-Z that
+// are spelled out rather than read as a word: a word with no vowel
+// letters, Y included, such as "SQL" and "HTML", or one of knownAcronyms,
+// such as "FBI" and "IEEE".  Other capitals, such as "NASA" and surnames
+// in upper-case lists such as "SMITH" and "LYNN", are read as words.
+func isAcronym(word string) bool {
+	if len(word) < 2 {
+		return false
+	}
+	vowels := false
+	for i := 0; i < len(word); i++ {
+		c := word[i]
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+		if isVowelLetter(c) || c == 'Y' {
+			vowels = true
+		}
+	}
+	return !vowels || knownAcronyms[word]
+}
+
+// isVowelLetter returns true if c is one of A, E, I, O and U.
+func isVowelLetter(c byte) bool {
+	switch c {
+	case 'A', 'E', 'I', 'O', 'U':
+		return true
+	}
+	return false
+}
+
+// encodeAcronym returns the joined codes of the names of the letters of
+// acronym, so "SQL" is encoded as "ess cue ell" is.
+func (enc *Encoder) encodeAcronym(acronym string) (metaph, metaph2 string) {
+	var parts []Codes
+	for i := 0; i < len(acronym); i++ {
+		for _, name := range enc.words(letterNames[acronym[i]-'A']) {
+			m, m2 := doubleMetaphone(name, enc.MaxLen, enc)
+			parts = append(parts, Codes{Word: name, Metaph: m, Metaph2: m2})
+		}
+	}
+	metaph, metaph2 = joinCodes(parts, "")
+	return enc.truncate(metaph), enc.truncate(metaph2)
+}
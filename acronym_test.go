@@ -0,0 +1,53 @@
+// acronym_test.go - test acronym.go.
+// This file is public domain.
+
+package metaphone
+
+import "testing"
+
+func TestAcronyms(t *testing.T) {
+	enc := &Encoder{MaxLen: 8, Acronyms: true}
+	tests := []struct {
+		word, like string
+	}{
+		{"SQL", "ess cue ell"},
+		{"IEEE", "eye ee ee ee"},
+		{"FBI", "eff bee eye"},
+		{"NASA", "nasa"},
+		{"UNICEF", "unicef"},
+		{"Sql", "sql"},
+		{"A", "a"},
+	}
+	plain := NewEncoder(8)
+	for _, tt := range tests {
+		m, m2 := enc.Encode(tt.word)
+		p := plain.EncodePhrase(tt.like)
+		if m != p.Metaph || m2 != p.Metaph2 {
+			t.Errorf("%s got: %s, %s;  want: %s, %s", tt.word, m, m2,
+				p.Metaph, p.Metaph2)
+		}
+	}
+	if m, _ := enc.Encode("SQL"); m != "ASKAL" {
+		t.Errorf("SQL got: %s;  want: ASKAL", m)
+	}
+}
+
+func TestAcronymsUpperCaseWords(t *testing.T) {
+	enc := &Encoder{MaxLen: 8, Acronyms: true}
+	plain := NewEncoder(8)
+	for _, word := range []string{"SMITH", "SCOTT", "BOOK", "JOHNSON",
+		"GREEN", "LYNN", "STREET", "NASA"} {
+		m, m2 := enc.Encode(word)
+		if p, p2 := plain.Encode(word); m != p || m2 != p2 {
+			t.Errorf("%s got: %s, %s;  want: %s, %s", word, m, m2, p, p2)
+		}
+		if isAcronym(word) {
+			t.Errorf("%s is read as an acronym", word)
+		}
+	}
+	for _, word := range []string{"HTML", "SQL", "FBI", "IEEE", "NYC"} {
+		if !isAcronym(word) {
+			t.Errorf("%s is not read as an acronym", word)
+		}
+	}
+}
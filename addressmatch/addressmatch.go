@@ -0,0 +1,175 @@
+// addressmatch.go - match street addresses by their components.
+// Created 2026-10-16 and placed in the public domain.
+
+// Package addressmatch matches street addresses for deduplication.  It
+// splits an address into its components, normalizes directionals and
+// street suffixes to their USPS abbreviations, and compares street names
+// by their DoubleMetaphone codes, so "123 N. Mane Street" matches
+// "123 North Main St".  Typical use:
+//
+//	import "github.com/charltoncr/metaphone/addressmatch"
+//	// ...
+//	m := addressmatch.NewMatcher(6)
+//	if m.Match("123 N. Mane Street", "123 North Main St") {
+//		// ...
+//	}
+package addressmatch
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charltoncr/metaphone"
+)
+
+// Address holds the components of a street address.  All components are
+// upper case.  Directionals and Suffix are USPS abbreviations, such as
+// "NE" and "AVE".
+type Address struct {
+	Number          string // house number, such as "123" or "123B"
+	PreDirectional  string // directional before the street name
+	Name            string // street name, such as "MAIN" or "SAINT CHARLES"
+	Suffix          string // street suffix, such as "ST" or "BLVD"
+	PostDirectional string // directional after the street suffix
+	Unit            string // unit, such as "APT 4" or "STE 200"
+}
+
+// directionals maps directionals to their USPS abbreviations.
+var directionals = map[string]string{
+	"N": "N", "NORTH": "N", "S": "S", "SOUTH": "S",
+	"E": "E", "EAST": "E", "W": "W", "WEST": "W",
+	"NE": "NE", "NORTHEAST": "NE", "NW": "NW", "NORTHWEST": "NW",
+	"SE": "SE", "SOUTHEAST": "SE", "SW": "SW", "SOUTHWEST": "SW",
+}
+
+// suffixes maps street suffixes to their USPS abbreviations.
+var suffixes = map[string]string{
+	"ALLEY": "ALY", "ALY": "ALY", "AVENUE": "AVE", "AVE": "AVE", "AV": "AVE",
+	"BOULEVARD": "BLVD", "BLVD": "BLVD", "CIRCLE": "CIR", "CIR": "CIR",
+	"COURT": "CT", "CT": "CT", "DRIVE": "DR", "DR": "DR",
+	"EXPRESSWAY": "EXPY", "EXPY": "EXPY", "FREEWAY": "FWY", "FWY": "FWY",
+	"HIGHWAY": "HWY", "HWY": "HWY", "LANE": "LN", "LN": "LN",
+	"PARKWAY": "PKWY", "PKWY": "PKWY", "PLACE": "PL", "PL": "PL",
+	"PLAZA": "PLZ", "PLZ": "PLZ", "ROAD": "RD", "RD": "RD",
+	"SQUARE": "SQ", "SQ": "SQ", "STREET": "ST", "ST": "ST", "STR": "ST",
+	"TERRACE": "TER", "TER": "TER", "TRAIL": "TRL", "TRL": "TRL",
+	"WAY": "WAY",
+}
+
+// units maps unit designators to their USPS abbreviations.
+var units = map[string]string{
+	"APARTMENT": "APT", "APT": "APT", "UNIT": "UNIT", "SUITE": "STE",
+	"STE": "STE", "ROOM": "RM", "RM": "RM", "FLOOR": "FL", "FL": "FL",
+	"#": "#",
+}
+
+// Tokenize returns the words of street address addr, upper-cased, with
+// periods and commas removed.  A '#' is a word of its own.
+func Tokenize(addr string) []string {
+	addr = strings.ReplaceAll(strings.ToUpper(addr), "#", " # ")
+	tokens := strings.FieldsFunc(addr, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ','
+	})
+	out := tokens[:0]
+	for _, t := range tokens {
+		if t = strings.ReplaceAll(t, ".", ""); len(t) > 0 {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Parse splits street address addr into its components.  A leading word
+// that starts with a digit is the house number, and a unit designator
+// such as "Apt", "Suite" or "#" starts the unit.  A directional or street
+// suffix is taken as such only if a street name remains, so
+// "123 North Ave" has the street name "NORTH".
+func Parse(addr string) (a Address) {
+	t := Tokenize(addr)
+	for i, w := range t {
+		if u, ok := units[w]; ok {
+			a.Unit = strings.Join(append([]string{u}, t[i+1:]...), " ")
+			t = t[:i]
+			break
+		}
+	}
+	if len(t) > 0 && unicode.IsDigit(rune(t[0][0])) {
+		a.Number, t = t[0], t[1:]
+	}
+	if d, ok := directionals[last(t)]; ok && len(t) > 1 {
+		a.PostDirectional, t = d, t[:len(t)-1]
+	}
+	if s, ok := suffixes[last(t)]; ok && len(t) > 1 {
+		a.Suffix, t = s, t[:len(t)-1]
+	}
+	if len(t) > 1 {
+		if d, ok := directionals[t[0]]; ok {
+			a.PreDirectional, t = d, t[1:]
+		}
+	}
+	a.Name = metaphone.NormalizePlace(strings.Join(t, " "))
+	a.Name = strings.ToUpper(a.Name)
+	return
+}
+
+// last returns the last word of t, or "" if t is empty.
+func last(t []string) string {
+	if len(t) == 0 {
+		return ""
+	}
+	return t[len(t)-1]
+}
+
+// Matcher matches street addresses.
+type Matcher struct {
+	// MaxLen is the maximum length of the DoubleMetaphone codes of street
+	// names compared.
+	MaxLen int
+	// MinStrength is the weakest match of street names that counts as a
+	// match.
+	MinStrength metaphone.Strength
+}
+
+// NewMatcher returns a Matcher that compares DoubleMetaphone codes of at
+// most maxLen characters and accepts a metaphone.Weak match of street
+// names.
+func NewMatcher(maxLen int) *Matcher {
+	return &Matcher{MaxLen: maxLen, MinStrength: metaphone.Weak}
+}
+
+// Match parses addresses a and b and reports whether they match.
+func (m *Matcher) Match(a, b string) bool {
+	return m.MatchAddresses(Parse(a), Parse(b))
+}
+
+// MatchAddresses reports whether addresses a and b match.  Their house
+// numbers and units must be equal, their directionals and suffixes must be
+// equal unless missing from either address, and their street names must
+// sound alike.  Street names with digits, such as "5TH", must be equal.
+func (m *Matcher) MatchAddresses(a, b Address) bool {
+	if a.Number != b.Number || a.Unit != b.Unit ||
+		!optionalEqual(a.PreDirectional, b.PreDirectional) ||
+		!optionalEqual(a.Suffix, b.Suffix) ||
+		!optionalEqual(a.PostDirectional, b.PostDirectional) {
+		return false
+	}
+	if a.Name == b.Name {
+		return true
+	}
+	if strings.ContainsFunc(a.Name+b.Name, unicode.IsDigit) {
+		return false
+	}
+	return metaphone.Compare(a.Name, b.Name, m.MaxLen) >= m.MinStrength
+}
+
+// optionalEqual reports whether x and y are equal or either is empty.
+func optionalEqual(x, y string) bool {
+	return x == y || len(x) == 0 || len(y) == 0
+}
+
+// StreetKeys returns the DoubleMetaphone codes of a's street name, for
+// grouping addresses that may match before comparing them with
+// MatchAddresses.
+func (m *Matcher) StreetKeys(a Address) (key, key2 string) {
+	return metaphone.DoubleMetaphone(a.Name, m.MaxLen)
+}
@@ -0,0 +1,66 @@
+// addressmatch_test.go - test addressmatch.go.
+// This file is public domain.
+
+package addressmatch
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	want := "[123 N MAIN ST # 4]"
+	if got := fmt.Sprint(Tokenize("123 N. Main St., #4")); got != want {
+		t.Errorf("got: %s;  want: %s", got, want)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Address
+	}{
+		{"123 N. Main Street", Address{Number: "123", PreDirectional: "N",
+			Name: "MAIN", Suffix: "ST"}},
+		{"1600 Pennsylvania Avenue NW", Address{Number: "1600",
+			Name: "PENNSYLVANIA", Suffix: "AVE", PostDirectional: "NW"}},
+		{"42 St. Charles Blvd, Apt 4", Address{Number: "42",
+			Name: "SAINT CHARLES", Suffix: "BLVD", Unit: "APT 4"}},
+		{"9 West Ave Suite 200", Address{Number: "9", Name: "WEST",
+			Suffix: "AVE", Unit: "STE 200"}},
+		{"77 5th Ave #12", Address{Number: "77", Name: "5TH", Suffix: "AVE",
+			Unit: "# 12"}},
+		{"", Address{}},
+	}
+	for _, tt := range tests {
+		if got := Parse(tt.in); got != tt.want {
+			t.Errorf("Parse(%q) got: %+v;  want: %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	m := NewMatcher(6)
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"123 N. Mane Street", "123 North Main St", true},
+		{"123 Main St", "123 Main Street Apt 4", false},
+		{"123 Main St", "125 Main St", false},
+		{"12 Smyth Rd", "12 Smith Road", true},
+		{"12 Smith Rd", "12 Smith Ln", false},
+		{"77 5th Ave", "77 Fifth Ave", false},
+		{"8 Oak Dr", "8 Elm Dr", false},
+	}
+	for _, tt := range tests {
+		if got := m.Match(tt.a, tt.b); got != tt.want {
+			t.Errorf("Match(%q, %q) got: %v;  want: %v", tt.a, tt.b, got,
+				tt.want)
+		}
+	}
+	key, _ := m.StreetKeys(Parse("123 Mane St"))
+	if want, _ := m.StreetKeys(Parse("9 Main Ave")); key != want {
+		t.Errorf("got: %s;  want: %s", key, want)
+	}
+}
@@ -0,0 +1,111 @@
+// arena.go - build a MetaphMap in bulk with few allocations.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import "strings"
+
+// arenaChunk is the size of each chunk of a stringArena.
+const arenaChunk = 64 << 10
+
+// stringArena makes strings in large shared chunks, so that millions of
+// short strings, such as codes, cost a few large allocations that the
+// garbage collector can scan quickly, instead of millions of small ones.
+type stringArena struct {
+	b strings.Builder
+}
+
+// string returns a copy of s in the arena.
+func (a *stringArena) string(s []byte) string {
+	if a.b.Cap()-a.b.Len() < len(s) {
+		// A strings.Builder never changes bytes once written, so strings
+		// already made from the old chunk stay valid.
+		a.b = strings.Builder{}
+		a.b.Grow(max(arenaChunk, len(s)))
+	}
+	start := a.b.Len()
+	a.b.Write(s)
+	return a.b.String()[start:]
+}
+
+// addBulk adds words to metaph as add does for each, but with the codes
+// kept in a stringArena and the buckets of all new codes cut from one
+// slice, so that loading a dictionary of millions of words makes few
+// allocations and little work for the garbage collector.  Only the codes
+// and buckets are allocated so; the words themselves are stored as given,
+// not copied into the arena.
+func (metaph *MetaphMap) addBulk(words []string) {
+	type entry struct {
+		word  string
+		m, m2 string
+	}
+	var arena stringArena
+	var res Result
+	codes := make(map[string]string) // interned new codes
+	counts := make(map[string]int)   // entries per new code
+	intern := func(code []byte) string {
+		if len(code) == 0 {
+			return ""
+		}
+		if c, ok := codes[string(code)]; ok {
+			return c
+		}
+		c := arena.string(code)
+		codes[c] = c
+		return c
+	}
+	entries := make([]entry, 0, len(words))
+	for _, word := range words {
+		word, isNew, _ := metaph.admit(word)
+		if !isNew {
+			continue
+		}
+		metaph.encodeInto(word, &res)
+		e := entry{word: word, m: intern(res.Metaph), m2: intern(res.Metaph2)}
+		for _, code := range []string{e.m, e.m2} {
+			if len(code) > 0 {
+				counts[code]++
+			}
+		}
+		entries = append(entries, e)
+	}
+
+	metaph.sorted, metaph.sortedWords = nil, nil
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	all := make([]string, 0, total)
+	for code, n := range counts {
+		bucket := metaph.mapper[code]
+		if len(bucket) == 0 {
+			// a new bucket, with room for exactly its words
+			bucket = all[len(all) : len(all) : len(all)+n]
+			all = all[:len(all)+n]
+		}
+		metaph.mapper[code] = bucket
+	}
+	for _, e := range entries {
+		if metaph.codes != nil {
+			metaph.codes[e.word] = [2]string{e.m, e.m2}
+		}
+		if len(e.m) > 0 {
+			metaph.mapper[e.m] = append(metaph.mapper[e.m], e.word)
+		}
+		if len(e.m2) > 0 {
+			metaph.mapper[e.m2] = append(metaph.mapper[e.m2], e.word)
+		}
+	}
+}
+
+// encodeInto puts the codes that encode returns for word in res.
+func (metaph *MetaphMap) encodeInto(word string, res *Result) {
+	word = metaph.Normalize(word)
+	if metaph.opts.Rhyme {
+		m, m2 := rhymeCodes(word)
+		res.Metaph = append(res.Metaph[:0], m...)
+		res.Metaph2 = append(res.Metaph2[:0], m2...)
+		return
+	}
+	metaph.enc.EncodeInto(word, res)
+}
@@ -0,0 +1,75 @@
+// arena_test.go - test arena.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func TestBulk(t *testing.T) {
+	words := []string{"Smith", "Smyth", "smith", "Schmidt", "the", "an",
+		"Thompson", "Tomson", "knight", "night", "Nite", "Xavier"}
+	build := func(b *Builder) *MetaphMap {
+		metaph, err := b.MaxLen(6).StopWords("the").MinLen(3).
+			Case(CaseFold).Words(words...).Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return metaph
+	}
+	want := build(NewBuilder())
+	got := build(NewBuilder().Bulk())
+	if got.Len() != want.Len() {
+		t.Errorf("Len got: %d;  want: %d", got.Len(), want.Len())
+	}
+	for _, word := range append(words, "smeeth", "nit") {
+		g, w := got.MatchWord(word), want.MatchWord(word)
+		sort.Strings(g)
+		sort.Strings(w)
+		if fmt.Sprint(g) != fmt.Sprint(w) {
+			t.Errorf("%s got: %v;  want: %v", word, g, w)
+		}
+	}
+	if n, w := got.freq["Smith"], want.freq["Smith"]; n != w {
+		t.Errorf("frequency got: %d;  want: %d", n, w)
+	}
+	// Appending to a bucket cut from the shared slice must not overwrite
+	// the next bucket.
+	got.add("Smitt")
+	for _, word := range []string{"night", "Thompson"} {
+		g, w := got.MatchWord(word), want.MatchWord(word)
+		sort.Strings(g)
+		sort.Strings(w)
+		if fmt.Sprint(g) != fmt.Sprint(w) {
+			t.Errorf("after add %s got: %v;  want: %v", word, g, w)
+		}
+	}
+}
+
+func TestStringArena(t *testing.T) {
+	var a stringArena
+	long := make([]byte, arenaChunk+1)
+	s := []string{a.string([]byte("ABC")), a.string(long), a.string([]byte("XY"))}
+	if s[0] != "ABC" || len(s[1]) != len(long) || s[2] != "XY" {
+		t.Errorf("got: %q %d %q;  want: \"ABC\" %d \"XY\"", s[0], len(s[1]),
+			s[2], len(long))
+	}
+}
+
+func BenchmarkBulkBuild(b *testing.B) {
+	words := make([]string, 100000)
+	for i := range words {
+		var w []byte
+		for n := i + 26*26; n > 0; n /= 26 {
+			w = append(w, byte('a'+n%26))
+		}
+		words[i] = string(w)
+	}
+	b.ResetTimer()
+	for range b.N {
+		NewBuilder().MaxLen(6).Bulk().Words(words...).Build()
+	}
+}
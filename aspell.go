@@ -0,0 +1,66 @@
+// aspell.go - read words from Aspell dictionaries.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// ParseAspellDump returns the words of an Aspell word list dump, as
+// written by "aspell dump master" or "aspell expand".  Each line holds one
+// or more words separated by spaces; affix flags after a slash, as in
+// "abandon/LSDG", are removed, so run the dump through "aspell expand" to
+// get the inflected forms the flags stand for.  The error is from reading
+// r, or wraps ErrDictionaryFormat if a line has flags but no word.
+func ParseAspellDump(r io.Reader) (words []string, err error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<20)
+	line := 0
+	for sc.Scan() {
+		line++
+		for _, w := range strings.Fields(sc.Text()) {
+			if i := strings.IndexByte(w, '/'); i >= 0 {
+				if i == 0 {
+					err = fmt.Errorf("%w: line %d of Aspell dump: %q",
+						ErrDictionaryFormat, line, w)
+					return
+				}
+				w = w[:i]
+			}
+			words = append(words, w)
+		}
+	}
+	if err = sc.Err(); err != nil {
+		err = fmt.Errorf("trying to read Aspell dump: %v", err)
+	}
+	return
+}
+
+// AspellWords returns the words of the installed Aspell dictionary for
+// lang, such as "en_US", with all affixes expanded.  It runs the aspell
+// program, which must be in the PATH.
+func AspellWords(lang string) (words []string, err error) {
+	var dump, expanded bytes.Buffer
+	var stderr strings.Builder
+	cmd := exec.Command("aspell", "-d", lang, "dump", "master")
+	cmd.Stdout, cmd.Stderr = &dump, &stderr
+	if err = cmd.Run(); err != nil {
+		err = fmt.Errorf("trying to dump Aspell dictionary %s: %v: %s",
+			lang, err, strings.TrimSpace(stderr.String()))
+		return
+	}
+	cmd = exec.Command("aspell", "-l", lang, "expand")
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = &dump, &expanded, &stderr
+	if err = cmd.Run(); err != nil {
+		err = fmt.Errorf("trying to expand Aspell dictionary %s: %v: %s",
+			lang, err, strings.TrimSpace(stderr.String()))
+		return
+	}
+	return ParseAspellDump(&expanded)
+}
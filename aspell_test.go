@@ -0,0 +1,41 @@
+// aspell_test.go - test aspell.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestParseAspellDump(t *testing.T) {
+	dump := "abandon/LSDG\nAaron's\nabandon abandons abandoned\n\n"
+	words, err := ParseAspellDump(strings.NewReader(dump))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "[abandon Aaron's abandon abandons abandoned]"
+	if got := fmt.Sprint(words); got != want {
+		t.Errorf("got: %s;  want: %s", got, want)
+	}
+	_, err = ParseAspellDump(strings.NewReader("ok\n/SG\n"))
+	if !errors.Is(err, ErrDictionaryFormat) {
+		t.Errorf("got: %v;  want ErrDictionaryFormat", err)
+	}
+}
+
+func TestAspellWords(t *testing.T) {
+	if _, err := exec.LookPath("aspell"); err != nil {
+		t.Skip("aspell is not installed")
+	}
+	metaph, err := NewBuilder().FromAspell("en").Build()
+	if err != nil {
+		t.Skip(err)
+	}
+	if !metaph.ContainsSoundAlike("knewmoanya") {
+		t.Errorf("no match for knewmoanya")
+	}
+}
@@ -0,0 +1,132 @@
+// blocklist.go - block terms that sound like banned words and phrases.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Blocklist finds banned words and phrases in text by sound, for content
+// moderation.  Text and entries are normalized alike with NormalizeLeet
+// and FoldConfusables, so disguises such as "fr33", "f.r.e.e" or Cyrillic
+// look-alike letters do not hide a banned term, and each word is matched
+// by its DoubleMetaphone codes, so misspellings do not either.  Words on
+// its allowlist are never blocked, to suppress false positives such as a
+// place name that sounds like a banned word.
+type Blocklist struct {
+	// words holds the entries of one word.
+	words *MetaphMap
+	// phrases maps the codes of the words of an entry of more than one
+	// word, joined by spaces, to the entries with those codes.
+	phrases map[string][]string
+	// maxWords is the number of words of the longest phrase.
+	maxWords int
+	// allow holds the normalized words of the allowlist.
+	allow map[string]bool
+}
+
+// NewBlocklist returns a Blocklist of entries, each a word or a phrase of
+// words separated by spaces, that compares DoubleMetaphone codes of at
+// most maxLen characters.
+func NewBlocklist(entries []string, maxLen int) *Blocklist {
+	bl := &Blocklist{
+		words: newMetaphMap(maxLen, &Options{
+			Normalizers: []Normalizer{NormalizeLeet, FoldConfusables},
+			Case:        CaseFold,
+		}),
+		phrases:  make(map[string][]string),
+		maxWords: 1,
+		allow:    make(map[string]bool),
+	}
+	for _, entry := range entries {
+		words := bl.tokens(entry)
+		switch len(words) {
+		case 0:
+		case 1:
+			bl.words.add(entry)
+		default:
+			for _, key := range bl.phraseKeys(words) {
+				bl.phrases[key] = append(bl.phrases[key], entry)
+			}
+			bl.maxWords = max(bl.maxWords, len(words))
+		}
+	}
+	return bl
+}
+
+// Allow adds words to bl's allowlist.
+func (bl *Blocklist) Allow(words ...string) {
+	for _, w := range words {
+		for _, t := range bl.tokens(w) {
+			bl.allow[t] = true
+		}
+	}
+}
+
+// IsBlocked returns true and the entry matched if a word of term, or a
+// run of its words, sounds like an entry of bl.  Words on the allowlist
+// are skipped, and a phrase entry matches only a run of words with none
+// on the allowlist.
+func (bl *Blocklist) IsBlocked(term string) (blocked bool, entry string) {
+	words := bl.tokens(term)
+	for i, w := range words {
+		if bl.allow[w] {
+			continue
+		}
+		if matches := bl.words.MatchWord(w); len(matches) > 0 {
+			slices.Sort(matches)
+			return true, matches[0]
+		}
+		for n := 2; n <= bl.maxWords && i+n <= len(words); n++ {
+			run := words[i : i+n]
+			if slices.ContainsFunc(run, func(w string) bool { return bl.allow[w] }) {
+				break
+			}
+			for _, key := range bl.phraseKeys(run) {
+				if entries := bl.phrases[key]; len(entries) > 0 {
+					return true, entries[0]
+				}
+			}
+		}
+	}
+	return
+}
+
+// tokens returns the words of s after normalizing it as bl's entries are.
+func (bl *Blocklist) tokens(s string) (words []string) {
+	s = bl.words.Normalize(s)
+	for _, f := range strings.Fields(s) {
+		f = strings.TrimFunc(f, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if len(f) > 0 {
+			words = append(words, f)
+		}
+	}
+	return
+}
+
+// phraseKeys returns the keys of phrases for normalized words: the codes
+// of each word joined by spaces, for each choice of the primary or
+// secondary code of each word that has both.
+func (bl *Blocklist) phraseKeys(words []string) []string {
+	keys := []string{""}
+	for i, w := range words {
+		m, m2 := bl.words.enc.Encode(w)
+		var next []string
+		for _, key := range keys {
+			if i > 0 {
+				key += " "
+			}
+			next = append(next, key+m)
+			if len(m2) > 0 && m2 != m {
+				next = append(next, key+m2)
+			}
+		}
+		keys = next
+	}
+	return keys
+}
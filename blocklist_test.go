@@ -0,0 +1,32 @@
+// blocklist_test.go - test blocklist.go.
+// This file is public domain.
+
+package metaphone
+
+import "testing"
+
+func TestBlocklist(t *testing.T) {
+	bl := NewBlocklist([]string{"freebie", "scam", "wire money now"}, 4)
+	bl.Allow("Scammell")
+	tests := []struct {
+		term    string
+		blocked bool
+		entry   string
+	}{
+		{"get a FREEBEE today", true, "freebie"},
+		{"fr33b13 inside", true, "freebie"},
+		{"this is a sk@m", true, "scam"},
+		{"ѕсаm", true, "scam"}, // Cyrillic ѕ, с, а
+		{"please wyre muny now!", true, "wire money now"},
+		{"wire the money now", false, ""},
+		{"Mr. Scammell called", false, ""},
+		{"hello there", false, ""},
+	}
+	for _, tt := range tests {
+		blocked, entry := bl.IsBlocked(tt.term)
+		if blocked != tt.blocked || entry != tt.entry {
+			t.Errorf("%q got: %v %q;  want: %v %q", tt.term, blocked, entry,
+				tt.blocked, tt.entry)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+// builder.go - build a MetaphMap one option at a time.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"context"
+	"net/http"
+)
+
+// Builder makes a MetaphMap from word lists and files with the options set
+// by its methods, each of which returns the Builder so calls can be
+// chained:
+//
+//	metaph, err := metaphone.NewBuilder().
+//		MaxLen(6).
+//		Normalizer(metaphone.StripPlural).
+//		StopWords("the", "and").
+//		FromFile("words.txt.gz").
+//		Build()
+//
+// A Builder can be reused; each Build makes a new MetaphMap.
+type Builder struct {
+	maxLen int
+	opts   Options
+	words  []string
+	files  []sourceSpec
+	aspell []string
+	urls   []urlSource
+	client *http.Client
+	bulk   bool
+}
+
+// urlSource is a URL given to FromURL and its cache directory.
+type urlSource struct {
+	rawURL, cacheDir string
+}
+
+// sourceSpec is a Source given to FromSource and its member patterns.
+type sourceSpec struct {
+	src      Source
+	patterns []string
+}
+
+// NewBuilder returns a Builder for a MetaphMap with a maximum code length
+// of 4, no words and the zero Options.
+func NewBuilder() *Builder {
+	return &Builder{maxLen: 4}
+}
+
+// MaxLen sets the maximum length of the codes words are stored under.
+func (b *Builder) MaxLen(maxLen int) *Builder {
+	b.maxLen = maxLen
+	return b
+}
+
+// Normalizer appends normalizers to those applied to each word.  See
+// Options.Normalizers.
+func (b *Builder) Normalizer(normalizers ...Normalizer) *Builder {
+	b.opts.Normalizers = append(b.opts.Normalizers, normalizers...)
+	return b
+}
+
+// StopWords appends words to those left out.  See Options.StopWords.
+func (b *Builder) StopWords(words ...string) *Builder {
+	b.opts.StopWords = append(b.opts.StopWords, words...)
+	return b
+}
+
+// MinLen sets the number of characters a word needs to be stored.  See
+// Options.MinLen.
+func (b *Builder) MinLen(n int) *Builder {
+	b.opts.MinLen = n
+	return b
+}
+
+// Case sets how the case of words is stored.  See Options.Case.
+func (b *Builder) Case(policy CasePolicy) *Builder {
+	b.opts.Case = policy
+	return b
+}
+
+// Rhyme makes the MetaphMap find rhymes.  See NewRhymeMap.
+func (b *Builder) Rhyme() *Builder {
+	b.opts.Rhyme = true
+	return b
+}
+
+// ReverseIndex keeps the codes of each word for RemoveWord.  See
+// Options.ReverseIndex.
+func (b *Builder) ReverseIndex() *Builder {
+	b.opts.ReverseIndex = true
+	return b
+}
+
+// Charset sets the character encoding of word list files.  See
+// Options.Charset.
+func (b *Builder) Charset(cs Charset) *Builder {
+	b.opts.Charset = cs
+	return b
+}
+
+// StrictWordlist makes Build fail on word list files with CRLF line
+// endings, stray whitespace or blank lines.  See Options.StrictWordlist.
+func (b *Builder) StrictWordlist() *Builder {
+	b.opts.StrictWordlist = true
+	return b
+}
+
+// Encoder sets the Encoder for words and queries.  See Options.Encoder.
+func (b *Builder) Encoder(enc *Encoder) *Builder {
+	b.opts.Encoder = enc
+	return b
+}
+
+// Bulk makes Build add all words at once, keeping their codes in a few
+// large shared blocks of memory.  That much reduces the time spent in
+// garbage collection when loading a dictionary of millions of words, at
+// the cost of holding all of its words in memory until Build returns.
+func (b *Builder) Bulk() *Builder {
+	b.bulk = true
+	return b
+}
+
+// Words appends words to those stored.
+func (b *Builder) Words(words ...string) *Builder {
+	b.words = append(b.words, words...)
+	return b
+}
+
+// FromFile appends the words of a word list file, as read by
+// NewMetaphMapFromFile, to those stored.  The file is read by Build.
+func (b *Builder) FromFile(fileName string) *Builder {
+	b.files = append(b.files, sourceSpec{src: FileSource(fileName)})
+	return b
+}
+
+// FromZip appends the words of the word list files in zip archive
+// fileName whose path or base name within the archive matches one of
+// patterns, as by path.Match, such as "*.txt" or "en_*/words.txt.gz", or
+// of all its files if there are no patterns, to those stored.  The archive
+// is read by Build.  Members with names ending with ".gz" are gunzipped.
+func (b *Builder) FromZip(fileName string, patterns ...string) *Builder {
+	return b.FromSource(FileSource(fileName), patterns...)
+}
+
+// FromSource appends the words of the word list of src to those stored.
+// If src is a zip archive, patterns select its members as for FromZip.
+// The word list is read by Build.
+func (b *Builder) FromSource(src Source, patterns ...string) *Builder {
+	b.files = append(b.files, sourceSpec{src, patterns})
+	return b
+}
+
+// FromURL appends the words of the word list at HTTP or HTTPS URL rawURL,
+// fetched into directory cacheDir by FetchWordlist, to those stored.  The
+// word list is fetched, or revalidated, by Build, with the client set by
+// HTTPClient.  If the fetch fails or times out, a cached copy is used.
+func (b *Builder) FromURL(rawURL, cacheDir string) *Builder {
+	b.urls = append(b.urls, urlSource{rawURL, cacheDir})
+	return b
+}
+
+// HTTPClient sets the client Build fetches the word lists of FromURL
+// with.  A nil client, the default, times out after FetchTimeout.
+func (b *Builder) HTTPClient(client *http.Client) *Builder {
+	b.client = client
+	return b
+}
+
+// FromAspell appends the words of the installed Aspell dictionary for
+// lang, as returned by AspellWords, to those stored.  The dictionary is
+// read by Build.
+func (b *Builder) FromAspell(lang string) *Builder {
+	b.aspell = append(b.aspell, lang)
+	return b
+}
+
+// Build returns a MetaphMap holding the words given to b, or an error if
+// a file, Source, URL or Aspell dictionary cannot be read or, if the
+// Encoder is Strict, a file has a word with an unsupported character.
+func (b *Builder) Build() (metaph *MetaphMap, err error) {
+	metaph = newMetaphMap(b.maxLen, &b.opts)
+	var pending []string
+	add := func(words []string) {
+		if b.bulk {
+			pending = append(pending, words...)
+			return
+		}
+		for _, word := range words {
+			metaph.add(word)
+		}
+	}
+	add(b.words)
+	files := b.files
+	for _, u := range b.urls {
+		var fileName string
+		if fileName, err = FetchWordlist(context.Background(), b.client, u.rawURL,
+			u.cacheDir); err != nil {
+			return nil, err
+		}
+		files = append(files[:len(files):len(files)],
+			sourceSpec{src: FileSource(fileName)})
+	}
+	for _, spec := range files {
+		var words []string
+		if words, err = readSource(spec.src, spec.patterns,
+			b.opts); err != nil {
+			return nil, err
+		}
+		add(words)
+	}
+	for _, lang := range b.aspell {
+		var words []string
+		if words, err = AspellWords(lang); err != nil {
+			return nil, err
+		}
+		add(words)
+	}
+	if b.bulk {
+		metaph.addBulk(pending)
+	}
+	return
+}
@@ -0,0 +1,43 @@
+// builder_test.go - test builder.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(name, []byte("Smiths\nthe\nSchmidt\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	metaph, err := NewBuilder().
+		MaxLen(6).
+		Normalizer(StripPlural).
+		StopWords("THE").
+		Case(CaseLower).
+		Words("Smith", "Smyth", "an").
+		MinLen(3).
+		FromFile(name).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := metaph.MatchWord("smith")
+	sort.Strings(got)
+	if want := "[schmidt smith smiths smyth]"; fmt.Sprint(got) != want {
+		t.Errorf("got: %v;  want: %s", got, want)
+	}
+	if got := metaph.MatchWord("the"); len(got) != 0 {
+		t.Errorf("stop word got: %v", got)
+	}
+	_, err = NewBuilder().FromFile(filepath.Join(t.TempDir(), "none")).Build()
+	if err == nil {
+		t.Errorf("missing file got nil error")
+	}
+}
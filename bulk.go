@@ -0,0 +1,28 @@
+// bulk.go - encode many words.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import "context"
+
+// bulkChunk is the number of words encoded between checks of a context.
+const bulkChunk = 1024
+
+// EncodeAllContext returns the DoubleMetaphone codes, of at most maxLen
+// characters, of each of words, in order.  It checks ctx before each chunk
+// of words, so a long job can be stopped cleanly; if ctx is done it
+// returns the codes made so far and ctx's error.
+func EncodeAllContext(ctx context.Context, words []string,
+	maxLen int) (codes []Codes, err error) {
+	codes = make([]Codes, 0, len(words))
+	for i, word := range words {
+		if i%bulkChunk == 0 {
+			if err = ctx.Err(); err != nil {
+				return
+			}
+		}
+		m, m2 := DoubleMetaphone(word, maxLen)
+		codes = append(codes, Codes{Word: word, Metaph: m, Metaph2: m2})
+	}
+	return
+}
@@ -0,0 +1,30 @@
+// bulk_test.go - test bulk.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestEncodeAllContext(t *testing.T) {
+	words := make([]string, 3*bulkChunk)
+	for i := range words {
+		words[i] = "Smith"
+	}
+	codes, err := EncodeAllContext(context.Background(), words, 4)
+	if err != nil || len(codes) != len(words) {
+		t.Fatalf("got %d codes, %v;  want %d, nil", len(codes), err, len(words))
+	}
+	if c := codes[len(codes)-1]; c.Metaph != "SM0" || c.Metaph2 != "XMT" {
+		t.Errorf("got: %+v;  want SM0, XMT", c)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	codes, err = EncodeAllContext(ctx, words, 4)
+	if !errors.Is(err, context.Canceled) || len(codes) != 0 {
+		t.Errorf("got %d codes, %v;  want 0, context.Canceled", len(codes), err)
+	}
+}
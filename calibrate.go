@@ -0,0 +1,161 @@
+// calibrate.go - turn match evidence into a 0-1 confidence.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"fmt"
+	"math"
+	"unicode/utf8"
+)
+
+// Evidence holds the raw evidence of how well two strings match: the
+// Strength of the match of their codes and their Similarity, which is 1
+// minus their EditDistance divided by the length of the longer string, so
+// it is 1 for strings equal but for case and 0 for strings with nothing
+// in common.
+type Evidence struct {
+	Strength   Strength
+	Similarity float64
+}
+
+// Evidence returns the evidence of how well a and b match, with codes
+// from enc.
+func (enc *Encoder) Evidence(a, b string) (e Evidence) {
+	m, m2 := enc.Encode(a)
+	n, n2 := enc.Encode(b)
+	e.Strength = compareCodes(m, m2, n, n2)
+	e.Similarity = 1
+	longest := max(utf8.RuneCountInString(a), utf8.RuneCountInString(b))
+	if longest > 0 {
+		e.Similarity -= float64(EditDistance(a, b)) / float64(longest)
+	}
+	return
+}
+
+// CalibrationWeights weighs Evidence in a Calibrator's logistic model.
+// Strong, Normal and Weak are added to Bias for a match of that
+// Strength; Similarity is multiplied by Evidence.Similarity.
+type CalibrationWeights struct {
+	Bias                 float64
+	Strong, Normal, Weak float64
+	Similarity           float64
+}
+
+// DefaultCalibrationWeights are the weights of NewCalibrator, chosen by
+// hand so that strings equal but for case have a confidence near 0.95, a
+// Strong match of similar spellings such as "Jon" and "John" near 0.9,
+// and strings with neither codes nor letters in common near 0.02.
+// Weights fit to labeled pairs from the caller's own data are better.
+var DefaultCalibrationWeights = CalibrationWeights{
+	Bias: -4, Strong: 3, Normal: 2.5, Weak: 2, Similarity: 4,
+}
+
+// Calibrator maps the Evidence of a match through a logistic model to a
+// confidence from 0 to 1 that two strings refer to the same thing, so
+// that downstream systems can set meaningful thresholds, such as "review
+// matches above 0.5 and accept those above 0.9".
+type Calibrator struct {
+	// Encoder encodes the strings compared.  A nil Encoder encodes like
+	// DoubleMetaphone with a maxlength of 4.
+	Encoder *Encoder
+	Weights CalibrationWeights
+}
+
+// NewCalibrator returns a Calibrator with DefaultCalibrationWeights and
+// an Encoder of codes of at most maxLen characters.
+func NewCalibrator(maxLen int) *Calibrator {
+	return &Calibrator{Encoder: NewEncoder(maxLen),
+		Weights: DefaultCalibrationWeights}
+}
+
+// Confidence returns the confidence, from 0 to 1, that a and b match.
+func (c *Calibrator) Confidence(a, b string) float64 {
+	return c.Calibrate(c.Encoder.Evidence(a, b))
+}
+
+// Calibrate returns the confidence, from 0 to 1, of a match with
+// evidence e.
+func (c *Calibrator) Calibrate(e Evidence) float64 {
+	x := features(e)
+	w := c.Weights
+	z := w.Bias + w.Strong*x[0] + w.Normal*x[1] + w.Weak*x[2] +
+		w.Similarity*x[3]
+	return 1 / (1 + math.Exp(-z))
+}
+
+// LabeledPair is a pair of strings known to match or not.
+type LabeledPair struct {
+	A, B  string
+	Match bool
+}
+
+// Fit sets c's weights by logistic regression on pairs, so that
+// Confidence estimates the probability that a pair like them matches.
+// The pairs must include both matching and non-matching pairs, or Fit
+// returns an error that wraps ErrTrainingData and leaves c unchanged.
+func (c *Calibrator) Fit(pairs []LabeledPair) (err error) {
+	var matches int
+	xs := make([][4]float64, len(pairs))
+	for i, p := range pairs {
+		xs[i] = features(c.Encoder.Evidence(p.A, p.B))
+		if p.Match {
+			matches++
+		}
+	}
+	if matches == 0 || matches == len(pairs) {
+		err = fmt.Errorf("%w: %d matching and %d non-matching pairs",
+			ErrTrainingData, matches, len(pairs)-matches)
+		return
+	}
+	// Batch gradient descent, with a little L2 regularization to keep
+	// the weights finite when the pairs are separable.
+	const (
+		rounds = 2000
+		rate   = 0.5
+		l2     = 1e-3
+	)
+	var bias float64
+	var w [4]float64
+	n := float64(len(pairs))
+	for range rounds {
+		var gb float64
+		var g [4]float64
+		for i, x := range xs {
+			z := bias
+			for j := range x {
+				z += w[j] * x[j]
+			}
+			d := 1 / (1 + math.Exp(-z))
+			if pairs[i].Match {
+				d--
+			}
+			gb += d
+			for j := range x {
+				g[j] += d * x[j]
+			}
+		}
+		bias -= rate * gb / n
+		for j := range w {
+			w[j] -= rate * (g[j]/n + l2*w[j])
+		}
+	}
+	c.Weights = CalibrationWeights{Bias: bias, Strong: w[0], Normal: w[1],
+		Weak: w[2], Similarity: w[3]}
+	return
+}
+
+// features returns e as the inputs of a Calibrator's model: an indicator
+// of each Strength of match, and Similarity.
+func features(e Evidence) (x [4]float64) {
+	switch e.Strength {
+	case Strong:
+		x[0] = 1
+	case Normal:
+		x[1] = 1
+	case Weak:
+		x[2] = 1
+	}
+	x[3] = e.Similarity
+	return
+}
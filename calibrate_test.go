@@ -0,0 +1,69 @@
+// calibrate_test.go - test calibrate.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEvidence(t *testing.T) {
+	enc := NewEncoder(4)
+	tests := []struct {
+		a, b       string
+		strength   Strength
+		similarity float64
+	}{
+		{"Smith", "SMITH", Strong, 1},
+		{"Jon", "John", Strong, 0.75},
+		{"abc", "xyz", NoMatch, 0},
+		{"", "", NoMatch, 1},
+	}
+	for _, tt := range tests {
+		e := enc.Evidence(tt.a, tt.b)
+		if e.Strength != tt.strength || math.Abs(e.Similarity-tt.similarity) > 1e-9 {
+			t.Errorf("%q, %q: got: %v;  want: %v", tt.a, tt.b, e,
+				Evidence{tt.strength, tt.similarity})
+		}
+	}
+}
+
+func TestCalibrator(t *testing.T) {
+	c := NewCalibrator(4)
+	if got := c.Confidence("Jon", "John"); math.Abs(got-1/(1+math.Exp(-2))) > 1e-9 {
+		t.Errorf("got: %v;  want: %v", got, 1/(1+math.Exp(-2)))
+	}
+	if got := c.Confidence("Smith", "Jones"); got > 0.05 {
+		t.Errorf("got: %v;  want: < 0.05", got)
+	}
+}
+
+func TestCalibratorFit(t *testing.T) {
+	pairs := []LabeledPair{
+		{"Jon", "John", true}, {"Smith", "Smyth", true},
+		{"Catherine", "Kathryn", true}, {"Philip", "Phillip", true},
+		{"Stephen", "Steven", true}, {"Gail", "Gayle", true},
+		{"Smith", "Jones", false}, {"Mary", "Robert", false},
+		{"Brown", "Green", false},
+		{"Carl", "Karen", false}, {"Marc", "Mark", true},
+	}
+	c := NewCalibrator(4)
+	if err := c.Fit(pairs); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range pairs {
+		if got := c.Confidence(p.A, p.B); got > 0.5 != p.Match {
+			t.Errorf("%q, %q: got: %v;  want match %v", p.A, p.B, got, p.Match)
+		}
+	}
+	w := c.Weights
+	err := c.Fit(pairs[:6])
+	if !errors.Is(err, ErrTrainingData) {
+		t.Errorf("got: %v;  want: %v", err, ErrTrainingData)
+	}
+	if c.Weights != w {
+		t.Errorf("failed Fit changed weights")
+	}
+}
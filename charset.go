@@ -0,0 +1,233 @@
+// charset.go - decode word lists that are not UTF-8.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Charset tells the character encoding of word list files.  Words are
+// encoded as UTF-8 text, so a word list in another character encoding
+// read as UTF-8 yields mojibake, such as "MÃ¼ller" for "Müller", whose
+// codes silently match nothing a user types.
+type Charset int
+
+const (
+	// CharsetUTF8 reads word lists as UTF-8, without conversion.
+	CharsetUTF8 Charset = iota
+	// CharsetAuto detects the encoding of each word list: UTF-16 if it
+	// starts with a UTF-16 byte order mark or has the zero bytes of
+	// UTF-16 text of Latin letters, UTF-8 if it is valid UTF-8, and
+	// Latin-1 otherwise.
+	CharsetAuto
+	// CharsetLatin1 reads word lists as ISO 8859-1 (Latin-1).
+	CharsetLatin1
+	// CharsetUTF16LE reads word lists as little-endian UTF-16.
+	CharsetUTF16LE
+	// CharsetUTF16BE reads word lists as big-endian UTF-16.
+	CharsetUTF16BE
+	// CharsetWindows1252 reads word lists as Windows-1252, the Latin-1
+	// of Windows, which has letters such as Š, Œ and Ÿ in place of the
+	// control characters 0x80 to 0x9F of Latin-1.
+	CharsetWindows1252
+	// CharsetLatin9 reads word lists as ISO 8859-15 (Latin-9), which
+	// differs from Latin-1 in eight characters, such as Š for ¦ and € for
+	// ¤.
+	CharsetLatin9
+)
+
+// String returns the name of cs, such as "UTF-8".
+func (cs Charset) String() string {
+	switch cs {
+	case CharsetAuto:
+		return "auto"
+	case CharsetLatin1:
+		return "ISO-8859-1"
+	case CharsetUTF16LE:
+		return "UTF-16LE"
+	case CharsetUTF16BE:
+		return "UTF-16BE"
+	case CharsetWindows1252:
+		return "windows-1252"
+	case CharsetLatin9:
+		return "ISO-8859-15"
+	}
+	return "UTF-8"
+}
+
+// Byte order marks of UTF-16 and UTF-8 text.
+var (
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+)
+
+// DetectCharset returns the encoding of word list b as CharsetAuto
+// detects it.  UTF-16 without a byte order mark is told by its zero
+// bytes, so UTF-16 text mostly of other scripts, such as Cyrillic or
+// Chinese, is not detected; give it a byte order mark or name its
+// Charset.
+func DetectCharset(b []byte) Charset {
+	switch {
+	case bytes.HasPrefix(b, bomUTF16LE):
+		return CharsetUTF16LE
+	case bytes.HasPrefix(b, bomUTF16BE):
+		return CharsetUTF16BE
+	}
+	// UTF-8 and Latin-1 text has no zero bytes, but UTF-16 text of Latin
+	// letters has one in every other position, whether or not it is also
+	// valid UTF-8, so this is checked first.
+	var even, odd int
+	for i, c := range b {
+		if c == 0 {
+			if i%2 == 0 {
+				even++
+			} else {
+				odd++
+			}
+		}
+	}
+	switch pairs := len(b) / 2; {
+	case pairs == 0:
+	case odd > pairs/2 && even == 0:
+		return CharsetUTF16LE
+	case even > pairs/2 && odd == 0:
+		return CharsetUTF16BE
+	}
+	if utf8.Valid(b) {
+		return CharsetUTF8
+	}
+	return CharsetLatin1
+}
+
+// windows1252 holds the characters of bytes 0x80 to 0x9F in Windows-1252.
+// The five bytes Windows-1252 does not define are read as in Latin-1.
+var windows1252 = [32]rune{
+	'€', 0x81, '‚', 'ƒ', '„', '…', '†', '‡',
+	'ˆ', '‰', 'Š', '‹', 'Œ', 0x8D, 'Ž', 0x8F,
+	0x90, '‘', '’', '“', '”', '•', '–', '—',
+	'˜', '™', 'š', '›', 'œ', 0x9D, 'ž', 'Ÿ',
+}
+
+// latin9 holds the characters of ISO 8859-15 that differ from those of
+// Latin-1, by byte.
+var latin9 = map[byte]rune{
+	0xA4: '€', 0xA6: 'Š', 0xA8: 'š', 0xB4: 'Ž', 0xB8: 'ž', 0xBC: 'Œ',
+	0xBD: 'œ', 0xBE: 'Ÿ',
+}
+
+// encodingDecl matches a declaration of the character encoding of a word
+// list in its first line, such as "# encoding: latin-1" or
+// "# -*- coding: utf-8 -*-".
+var encodingDecl = regexp.MustCompile(
+	`(?i)^\s*#.*?\b(?:en)?coding\s*[:=]\s*([-\w.]+)`)
+
+// ParseCharset returns the Charset named name, such as "UTF-8",
+// "latin-1", "ISO-8859-1", "cp1252", "ISO-8859-15", "UTF-16LE" or
+// "auto".  Case, hyphens and underscores in name are ignored.
+func ParseCharset(name string) (cs Charset, err error) {
+	switch strings.NewReplacer("-", "", "_", "").Replace(
+		strings.ToLower(name)) {
+	case "utf8":
+		return CharsetUTF8, nil
+	case "auto":
+		return CharsetAuto, nil
+	case "latin1", "iso88591", "l1":
+		return CharsetLatin1, nil
+	case "utf16le":
+		return CharsetUTF16LE, nil
+	case "utf16be", "utf16":
+		return CharsetUTF16BE, nil
+	case "windows1252", "cp1252", "win1252":
+		return CharsetWindows1252, nil
+	case "iso885915", "latin9", "l9":
+		return CharsetLatin9, nil
+	}
+	err = fmt.Errorf("unknown character encoding %q", name)
+	return
+}
+
+// decodeCharset returns word list b as UTF-8 text.  Its character
+// encoding is given by a byte order mark, if b starts with one, else by
+// an encoding declaration in its first line, such as
+// "# encoding: latin-1", else by cs.  The byte order mark and the line of
+// the declaration are removed, so the first word of b does not get an
+// invisible prefix that changes its codes; line is the number of the
+// line of b that text starts with, 2 if a declaration was removed and 1
+// otherwise.  An error is returned if the declaration names an unknown
+// encoding or b is UTF-16 of an odd number of bytes.
+func decodeCharset(b []byte, cs Charset) (text string, line int,
+	err error) {
+	line = 1
+	bom := true
+	switch {
+	case bytes.HasPrefix(b, bomUTF8):
+		b, cs = b[len(bomUTF8):], CharsetUTF8
+	case bytes.HasPrefix(b, bomUTF16LE):
+		b, cs = b[len(bomUTF16LE):], CharsetUTF16LE
+	case bytes.HasPrefix(b, bomUTF16BE):
+		b, cs = b[len(bomUTF16BE):], CharsetUTF16BE
+	default:
+		bom = false
+	}
+	if cs != CharsetUTF16LE && cs != CharsetUTF16BE {
+		// The declaration is ASCII, so it can be read before the
+		// encoding of the rest of b is known.
+		first, rest, _ := bytes.Cut(b, []byte("\n"))
+		if m := encodingDecl.FindSubmatch(first); m != nil {
+			var declared Charset
+			if declared, err = ParseCharset(string(m[1])); err != nil {
+				return
+			}
+			if !bom {
+				cs = declared
+			}
+			b, line = rest, 2
+		}
+	}
+	if cs == CharsetAuto {
+		cs = DetectCharset(b)
+	}
+	switch cs {
+	case CharsetLatin1, CharsetWindows1252, CharsetLatin9:
+		r := make([]rune, len(b))
+		for i, c := range b {
+			r[i] = rune(c)
+			switch {
+			case cs == CharsetWindows1252 && c >= 0x80 && c < 0xA0:
+				r[i] = windows1252[c-0x80]
+			case cs == CharsetLatin9 && latin9[c] != 0:
+				r[i] = latin9[c]
+			}
+		}
+		text = string(r)
+	case CharsetUTF16LE, CharsetUTF16BE:
+		if len(b)%2 != 0 {
+			err = fmt.Errorf("%v text has an odd number of bytes, %d", cs,
+				len(b))
+			return
+		}
+		var order binary.ByteOrder = binary.LittleEndian
+		if cs == CharsetUTF16BE {
+			order = binary.BigEndian
+		}
+		u := make([]uint16, len(b)/2)
+		for i := range u {
+			u[i] = order.Uint16(b[2*i:])
+		}
+		text = string(utf16.Decode(u))
+		if first, rest, _ := strings.Cut(text, "\n"); encodingDecl.MatchString(first) {
+			text, line = rest, 2
+		}
+	default:
+		text = string(b)
+	}
+	return
+}
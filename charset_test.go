@@ -0,0 +1,136 @@
+// charset_test.go - test charset.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+// utf16Bytes returns s in UTF-16, big-endian if be is true, after bom.
+func utf16Bytes(s string, be bool, bom []byte) []byte {
+	b := append([]byte(nil), bom...)
+	for _, u := range utf16.Encode([]rune(s)) {
+		if be {
+			b = append(b, byte(u>>8), byte(u))
+		} else {
+			b = append(b, byte(u), byte(u>>8))
+		}
+	}
+	return b
+}
+
+func TestDetectCharset(t *testing.T) {
+	tests := []struct {
+		b    []byte
+		want Charset
+		text string
+	}{
+		{[]byte("Müller\n"), CharsetUTF8, "Müller\n"},
+		{[]byte("M\xfcller\n"), CharsetLatin1, "Müller\n"},
+		{utf16Bytes("Müller\n", false, bomUTF16LE), CharsetUTF16LE, "Müller\n"},
+		{utf16Bytes("Müller\n", true, bomUTF16BE), CharsetUTF16BE, "Müller\n"},
+		{utf16Bytes("Smith\n", false, nil), CharsetUTF16LE, "Smith\n"},
+		{utf16Bytes("Smith\n", true, nil), CharsetUTF16BE, "Smith\n"},
+		{utf16Bytes("Müller\nSmith\n", false, nil), CharsetUTF16LE,
+			"Müller\nSmith\n"},
+		{utf16Bytes("Müller\nSmith\n", true, nil), CharsetUTF16BE,
+			"Müller\nSmith\n"},
+		{nil, CharsetUTF8, ""},
+	}
+	for _, tt := range tests {
+		if got := DetectCharset(tt.b); got != tt.want {
+			t.Errorf("%q got: %v;  want: %v", tt.b, got, tt.want)
+		}
+		if got, _, _ := decodeCharset(tt.b, CharsetAuto); got != tt.text {
+			t.Errorf("%q decoded got: %q;  want: %q", tt.b, got, tt.text)
+		}
+	}
+}
+
+func TestBuilderCharset(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "latin1.txt")
+	if err := os.WriteFile(fileName, []byte("M\xfcller\nSmith\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	metaph, err := NewBuilder().Charset(CharsetAuto).FromFile(fileName).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := metaph.MatchWord("Müller"); len(got) != 1 || got[0] != "Müller" {
+		t.Errorf("got: %q;  want: [Müller]", got)
+	}
+	metaph, err = NewBuilder().FromFile(fileName).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := metaph.MatchWord("Müller"); len(got) == 1 && got[0] == "Müller" {
+		t.Errorf("UTF-8 got: %q;  want mojibake", got)
+	}
+}
+
+func TestDecodeCharsetDeclaration(t *testing.T) {
+	tests := []struct {
+		b    []byte
+		cs   Charset
+		text string
+	}{
+		{[]byte("\xef\xbb\xbfApple\n"), CharsetUTF8, "Apple\n"},
+		{[]byte("\xef\xbb\xbfM\xc3\xbcller\n"), CharsetLatin1, "Müller\n"},
+		{utf16Bytes("Apple\n", false, bomUTF16LE), CharsetUTF8, "Apple\n"},
+		{[]byte("# encoding: latin-1\nM\xfcller\n"), CharsetUTF8, "Müller\n"},
+		{[]byte("# -*- coding: UTF-8 -*-\nMüller\n"), CharsetLatin1, "Müller\n"},
+		{[]byte("\xef\xbb\xbf# encoding: latin-1\nMüller\n"), CharsetUTF8,
+			"Müller\n"},
+		{utf16Bytes("# encoding: utf-16\nApple\n", true, bomUTF16BE),
+			CharsetUTF8, "Apple\n"},
+		{[]byte("#comment\nApple\n"), CharsetUTF8, "#comment\nApple\n"},
+		{[]byte("# encoding: cp1252\n\x8aimon \x9cuvre\n"), CharsetUTF8,
+			"Šimon œuvre\n"},
+		{[]byte("# encoding: windows-1252\nM\xfcller\n"), CharsetUTF8,
+			"Müller\n"},
+		{[]byte("# encoding: iso-8859-15\n\xa6imon \xbduvre\n"), CharsetUTF8,
+			"Šimon œuvre\n"},
+		{[]byte("\x8aimon\n"), CharsetWindows1252, "Šimon\n"},
+	}
+	for _, tt := range tests {
+		got, _, err := decodeCharset(tt.b, tt.cs)
+		if err != nil || got != tt.text {
+			t.Errorf("%q got: %q, %v;  want: %q", tt.b, got, err, tt.text)
+		}
+	}
+	if _, _, err := decodeCharset([]byte("# encoding: klingon\n"), CharsetUTF8); err == nil {
+		t.Errorf("unknown encoding got: nil;  want: error")
+	}
+	odd := utf16Bytes("Apple\n", false, bomUTF16LE)
+	if _, _, err := decodeCharset(odd[:len(odd)-1], CharsetUTF8); err == nil {
+		t.Errorf("odd UTF-16 got: nil;  want: error")
+	}
+}
+
+func TestReadSourceBOM(t *testing.T) {
+	src := NewSource("words.txt", func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("\ufeffThomas\nTomas\n")), nil
+	})
+	metaph, err := NewMetaphMapFromSource(src, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(metaph.MatchWordSorted("Thomas", SortAlphabetical)); got != "[Thomas Tomas]" {
+		t.Errorf("got: %s;  want: [Thomas Tomas]", got)
+	}
+	src = NewSource("words.txt", func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("# coding: ebcdic\nThomas\n")), nil
+	})
+	if _, err = NewMetaphMapFromSource(src, 4); !errors.Is(err, ErrDictionaryFormat) {
+		t.Errorf("got: %v;  want: %v", err, ErrDictionaryFormat)
+	}
+}
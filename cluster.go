@@ -0,0 +1,88 @@
+// cluster.go - cluster a word list into groups of sound-alike words.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import "sort"
+
+// Clusters returns the connected components of sound-alike words in
+// wordlist: two words are in the same cluster if they share a
+// DoubleMetaphone code of at most maxLen characters, directly or through
+// other words.  Duplicate words are included once.  Clusters are sorted
+// largest first, and clusters of equal size by their first word; words in
+// a cluster are in the order of wordlist.  The sizes of the clusters show
+// how discriminating the codes are for wordlist.
+func Clusters(wordlist []string, maxLen int) [][]string {
+	var words []string
+	seen := make(map[string]bool)
+	for _, w := range wordlist {
+		if !seen[w] {
+			seen[w] = true
+			words = append(words, w)
+		}
+	}
+
+	uf := newUnionFind(len(words))
+	first := make(map[string]int) // first word with each code
+	for i, w := range words {
+		m, m2 := DoubleMetaphone(w, maxLen)
+		for _, code := range []string{m, m2} {
+			if len(code) == 0 {
+				continue
+			}
+			if j, ok := first[code]; ok {
+				uf.union(i, j)
+			} else {
+				first[code] = i
+			}
+		}
+	}
+
+	index := make(map[int]int) // root to cluster
+	var clusters [][]string
+	for i, w := range words {
+		root := uf.find(i)
+		c, ok := index[root]
+		if !ok {
+			c = len(clusters)
+			index[root] = c
+			clusters = append(clusters, nil)
+		}
+		clusters[c] = append(clusters[c], w)
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		if len(clusters[i]) != len(clusters[j]) {
+			return len(clusters[i]) > len(clusters[j])
+		}
+		return clusters[i][0] < clusters[j][0]
+	})
+	return clusters
+}
+
+// unionFind is a disjoint-set forest.
+type unionFind []int
+
+// newUnionFind returns a unionFind of n singleton sets.
+func newUnionFind(n int) unionFind {
+	uf := make(unionFind, n)
+	for i := range uf {
+		uf[i] = i
+	}
+	return uf
+}
+
+// find returns the root of the set holding i.
+func (uf unionFind) find(i int) int {
+	for uf[i] != i {
+		uf[i] = uf[uf[i]]
+		i = uf[i]
+	}
+	return i
+}
+
+// union merges the sets holding i and j.
+func (uf unionFind) union(i, j int) {
+	if ri, rj := uf.find(i), uf.find(j); ri != rj {
+		uf[ri] = rj
+	}
+}
@@ -0,0 +1,110 @@
+// bench.go - the bench command measures encoding throughput.
+// Created 2026-10-16 and placed in the public domain.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/charltoncr/metaphone"
+)
+
+// runBench encodes the words of a word list repeatedly with
+// DoubleMetaphone for each of a list of maxLen values and writes, for
+// each, the encodes per second and the heap allocations and bytes per
+// encode, so releases and hardware can be compared without writing Go
+// benchmarks.
+func runBench(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := newFlagSet("bench", "[wordlist]")
+	maxLens := fs.String("maxlens", "4,6,8", "comma-separated maxLen values")
+	dur := fs.Duration("time", time.Second, "time to run for each maxLen")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return fmt.Errorf("bench reads one word list")
+	}
+	var lens []int
+	for _, s := range strings.Split(*maxLens, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil || n < 1 {
+			return fmt.Errorf("bad maxLen %q in -maxlens", s)
+		}
+		lens = append(lens, n)
+	}
+	words, err := readWords(inputs(fs.Args())[0], stdin)
+	if err != nil {
+		return err
+	}
+	if len(words) == 0 {
+		return fmt.Errorf("bench needs a word list with at least one word")
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "maxlen\tencodes/s\tns/encode\tallocs/encode\tB/encode\t\n")
+	for _, maxLen := range lens {
+		r := benchEncode(words, maxLen, *dur)
+		fmt.Fprintf(tw, "%d\t%.0f\t%.1f\t%.2f\t%.1f\t\n", maxLen,
+			float64(r.n)/r.elapsed.Seconds(),
+			float64(r.elapsed.Nanoseconds())/float64(r.n),
+			float64(r.allocs)/float64(r.n), float64(r.bytes)/float64(r.n))
+	}
+	return tw.Flush()
+}
+
+// benchResult holds the number of encodes made in a benchmark, the time
+// they took, and the heap allocations and bytes they made.
+type benchResult struct {
+	n             int
+	elapsed       time.Duration
+	allocs, bytes uint64
+}
+
+// benchEncode encodes words with maxLen, over and over, for at least dur,
+// and at least once each.
+func benchEncode(words []string, maxLen int, dur time.Duration) (
+	r benchResult) {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for r.n == 0 || time.Since(start) < dur {
+		for _, w := range words {
+			metaphone.DoubleMetaphone(w, maxLen)
+		}
+		r.n += len(words)
+	}
+	r.elapsed = time.Since(start)
+	runtime.ReadMemStats(&after)
+	r.allocs = after.Mallocs - before.Mallocs
+	r.bytes = after.TotalAlloc - before.TotalAlloc
+	return
+}
+
+// readWords returns the nonblank lines of file name, or stdin if name is
+// "-", trimmed of spaces.
+func readWords(name string, stdin io.Reader) (words []string, err error) {
+	var r io.ReadCloser
+	if r, err = openInput(name, stdin); err != nil {
+		return
+	}
+	defer r.Close()
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		if w := strings.TrimSpace(sc.Text()); len(w) > 0 {
+			words = append(words, w)
+		}
+	}
+	if err = sc.Err(); err != nil {
+		err = fmt.Errorf("trying to read word list %s: %v", name, err)
+	}
+	return
+}
@@ -0,0 +1,134 @@
+// dedupe.go - the dedupe command finds probable duplicates in a contact
+// list.
+// Created 2026-10-16 and placed in the public domain.
+
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/charltoncr/metaphone"
+)
+
+// runDedupe reads a contact list CSV file and writes its clusters of
+// probable duplicates as CSV, each row prefixed with its cluster number.
+// Two rows are duplicates if their names score at least the threshold, as
+// for the link command, and each of the secondary fields named by -fields
+// agrees where both rows have it.  Duplicates of duplicates are in the
+// same cluster.  Rows with no duplicate are not written.
+func runDedupe(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := newFlagSet("dedupe", "[contacts.csv]")
+	nameCol := fs.String("col", "name", "name column")
+	fields := fs.String("fields", "",
+		"comma-separated secondary columns that must agree, such as email,phone")
+	threshold := fs.Float64("threshold", 0.8, "minimum name score, 0 to 1")
+	maxLen := fs.Int("maxlen", 4, "maximum code length")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return fmt.Errorf("dedupe reads one CSV file")
+	}
+	name := inputs(fs.Args())[0]
+	header, rows, err := readCSV(name, stdin)
+	if err != nil {
+		return err
+	}
+	ni, err := column(header, *nameCol)
+	if err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	var others []int
+	for _, f := range strings.Split(*fields, ",") {
+		if f = strings.TrimSpace(f); len(f) > 0 {
+			i, err := column(header, f)
+			if err != nil {
+				return fmt.Errorf("%s: %v", name, err)
+			}
+			others = append(others, i)
+		}
+	}
+
+	agree := func(a, b []string) bool {
+		for _, i := range others {
+			x, y := fieldKey(field(a, i)), fieldKey(field(b, i))
+			if len(x) > 0 && len(y) > 0 && x != y {
+				return false
+			}
+		}
+		return true
+	}
+	parent := make([]int, len(rows))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	byCode := make(map[string][]int)
+	for i, row := range rows {
+		m, m2 := metaphone.DoubleMetaphone(field(row, ni), *maxLen)
+		seen := make(map[int]bool)
+		for _, j := range append(byCode[m], byCode[m2]...) {
+			if seen[j] {
+				continue
+			}
+			seen[j] = true
+			if nameScore(field(row, ni), field(rows[j], ni),
+				*maxLen) >= *threshold && agree(row, rows[j]) {
+				ri, rj := find(i), find(j)
+				parent[max(ri, rj)] = min(ri, rj)
+			}
+		}
+		for _, code := range []string{m, m2} {
+			if len(code) > 0 {
+				byCode[code] = append(byCode[code], i)
+			}
+		}
+	}
+
+	clusters := make(map[int][]int)
+	var roots []int
+	for i := range rows {
+		r := find(i)
+		if len(clusters[r]) == 0 {
+			roots = append(roots, r)
+		}
+		clusters[r] = append(clusters[r], i)
+	}
+	w := csv.NewWriter(stdout)
+	w.Write(append([]string{"cluster"}, header...))
+	n := 0
+	for _, r := range roots {
+		if len(clusters[r]) < 2 {
+			continue
+		}
+		n++
+		for _, i := range clusters[r] {
+			w.Write(append([]string{strconv.Itoa(n)}, rows[i]...))
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// fieldKey returns the letters and digits of secondary field s,
+// lower-cased, so "(555) 010-2345" and "555.010.2345" agree.
+func fieldKey(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
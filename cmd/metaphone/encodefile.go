@@ -0,0 +1,123 @@
+// encodefile.go - the encode-file command writes the codes of a large word
+// list as TSV.
+// Created 2026-10-16 and placed in the public domain.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/charltoncr/metaphone"
+)
+
+// encodeBatch is the number of words a worker encodes at a time.
+const encodeBatch = 4096
+
+// batch is a batch of words and the channel its encoded lines are sent
+// on.
+type batch struct {
+	words []string
+	out   chan []byte
+}
+
+// runEncodeFile streams the words of a word list, one per line, and writes
+// each as "word<TAB>primary<TAB>secondary", for offline index preparation.
+// Words are encoded by parallel workers and written in input order.
+// Progress is reported on stderr.
+func runEncodeFile(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := newFlagSet("encode-file", "[wordlist]")
+	outName := fs.String("o", "-", "output file, - for stdout")
+	maxLen := fs.Int("maxlen", 4, "maximum code length")
+	workers := fs.Int("workers", runtime.NumCPU(), "number of parallel workers")
+	every := fs.Duration("progress", time.Second,
+		"interval between progress reports on stderr, 0 for none")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return fmt.Errorf("encode-file reads one word list")
+	}
+	r, err := openInput(inputs(fs.Args())[0], stdin)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	var fp *os.File
+	if *outName != "-" {
+		if fp, err = os.Create(*outName); err != nil {
+			return err
+		}
+		defer fp.Close()
+		stdout = fp
+	}
+	w := bufio.NewWriter(stdout)
+
+	jobs := make(chan batch)
+	order := make(chan batch, max(*workers, 1))
+	var readErr error
+	go func() {
+		defer close(jobs)
+		defer close(order)
+		sc := bufio.NewScanner(r)
+		words := make([]string, 0, encodeBatch)
+		send := func() {
+			b := batch{words, make(chan []byte, 1)}
+			order <- b
+			jobs <- b
+			words = make([]string, 0, encodeBatch)
+		}
+		for sc.Scan() {
+			word := strings.TrimSpace(strings.ReplaceAll(sc.Text(), "\t", " "))
+			if len(word) == 0 {
+				continue
+			}
+			if words = append(words, word); len(words) == encodeBatch {
+				send()
+			}
+		}
+		if len(words) > 0 {
+			send()
+		}
+		readErr = sc.Err()
+	}()
+	for range max(*workers, 1) {
+		go func() {
+			for b := range jobs {
+				var buf []byte
+				for _, word := range b.words {
+					m, m2 := metaphone.DoubleMetaphone(word, *maxLen)
+					buf = fmt.Appendf(buf, "%s\t%s\t%s\n", word, m, m2)
+				}
+				b.out <- buf
+			}
+		}()
+	}
+
+	start, last, n := time.Now(), time.Now(), 0
+	for b := range order {
+		w.Write(<-b.out)
+		n += len(b.words)
+		if *every > 0 && time.Since(last) >= *every {
+			last = time.Now()
+			fmt.Fprintf(os.Stderr, "encode-file: %d words, %.0f words/s\n",
+				n, float64(n)/time.Since(start).Seconds())
+		}
+	}
+	if readErr != nil {
+		return fmt.Errorf("trying to read word list: %v", readErr)
+	}
+	if err = w.Flush(); err != nil {
+		return err
+	}
+	if fp != nil {
+		return fp.Close()
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+// golden.go - the golden command writes reference codes for word lists.
+// Created 2026-10-16 and placed in the public domain.
+
+package main
+
+import (
+	"io"
+
+	"github.com/charltoncr/metaphone"
+)
+
+// runGolden writes the DoubleMetaphone codes of the words in the files
+// named by args, or stdin, in the reference format of the package's test
+// data, so a team can pin golden files for its own vocabulary and check
+// them later with metaphone.VerifyAgainstReference.
+func runGolden(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := newFlagSet("golden", "[wordlist ...]")
+	maxLen := fs.Int("maxlen", 6, "maximum code length")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	for _, name := range inputs(fs.Args()) {
+		r, err := openInput(name, stdin)
+		if err != nil {
+			return err
+		}
+		err = metaphone.WriteReference(stdout, r, *maxLen)
+		r.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
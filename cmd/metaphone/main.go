@@ -0,0 +1,177 @@
+// main.go - the metaphone command, which runs tools built on package
+// metaphone.
+// Created 2026-10-16 and placed in the public domain.
+
+// Command metaphone runs tools built on package metaphone.  Usage:
+//
+//	metaphone <command> [flags] [args]
+//
+// Run "metaphone help" for the list of commands and "metaphone <command>
+// -h" for a command's flags.
+package main
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// command is a subcommand of metaphone.
+type command struct {
+	// summary is a one-line description for "metaphone help".
+	summary string
+	// run runs the command with its arguments, reading from stdin and
+	// writing to stdout.
+	run func(args []string, stdin io.Reader, stdout io.Writer) error
+}
+
+// commands are the subcommands of metaphone by name.
+var commands = map[string]command{
+	"bench": {"measure encoding throughput on a word list", runBench},
+	"dedupe": {"find probable duplicates in a contact list CSV file",
+		runDedupe},
+	"encode-file": {"write the codes of a large word list as TSV",
+		runEncodeFile},
+	"golden": {"write reference codes for a word list", runGolden},
+	"link":   {"join two CSV files on sound-alike names", runLink},
+	"symbols": {"find identifiers in source code that sound like a query",
+		runSymbols},
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdin, os.Stdout)
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		os.Exit(2)
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "metaphone:", err)
+		os.Exit(1)
+	}
+}
+
+// run runs the command named by args[0] with the rest of args.
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" {
+		usage(stdout)
+		return nil
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q; run \"metaphone help\"", args[0])
+	}
+	return cmd.run(args[1:], stdin, stdout)
+}
+
+// usage writes the list of commands to w.
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: metaphone <command> [flags] [args]\n\ncommands:")
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-12s %s\n", name, commands[name].summary)
+	}
+}
+
+// newFlagSet returns a FlagSet for command name that returns errors
+// rather than exiting.
+func newFlagSet(name, argsUsage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: metaphone %s [flags] %s\n",
+			name, argsUsage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// openInput opens file name for reading, decompressing it if its name
+// ends with ".gz".  Name "-" is stdin.
+func openInput(name string, stdin io.Reader) (r io.ReadCloser, err error) {
+	if name == "-" {
+		return io.NopCloser(stdin), nil
+	}
+	var fp *os.File
+	if fp, err = os.Open(name); err != nil {
+		return
+	}
+	if !strings.HasSuffix(name, ".gz") {
+		return fp, nil
+	}
+	var zr *gzip.Reader
+	if zr, err = gzip.NewReader(fp); err != nil {
+		fp.Close()
+		return nil, fmt.Errorf("trying to make a gzip reader for file %s: %v",
+			name, err)
+	}
+	return &gzipFile{zr, fp}, nil
+}
+
+// gzipFile closes both a gzip.Reader and its file.
+type gzipFile struct {
+	*gzip.Reader
+	fp *os.File
+}
+
+// Close closes g's reader and file.
+func (g *gzipFile) Close() error {
+	g.Reader.Close()
+	return g.fp.Close()
+}
+
+// inputs returns the files named in args, or stdin if there are none.
+func inputs(args []string) []string {
+	if len(args) == 0 {
+		return []string{"-"}
+	}
+	return args
+}
+
+// readCSV reads CSV file name, or stdin if name is "-", and returns its
+// header row and its other rows.
+func readCSV(name string, stdin io.Reader) (header []string, rows [][]string,
+	err error) {
+	var r io.ReadCloser
+	if r, err = openInput(name, stdin); err != nil {
+		return
+	}
+	defer r.Close()
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	if rows, err = cr.ReadAll(); err != nil {
+		err = fmt.Errorf("trying to read CSV file %s: %v", name, err)
+		return
+	}
+	if len(rows) == 0 {
+		err = fmt.Errorf("CSV file %s is empty", name)
+		return
+	}
+	return rows[0], rows[1:], nil
+}
+
+// column returns the index of the column of header named name, ignoring
+// case.
+func column(header []string, name string) (int, error) {
+	for i, h := range header {
+		if strings.EqualFold(strings.TrimSpace(h), name) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("no column %q in header %q", name, header)
+}
+
+// field returns row[i], or "" if row is too short.
+func field(row []string, i int) string {
+	if i < len(row) {
+		return row[i]
+	}
+	return ""
+}
@@ -0,0 +1,143 @@
+// main_test.go - test the metaphone command.
+// This file is public domain.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runCmd runs metaphone with args and stdin and returns its output.
+func runCmd(t *testing.T, stdin string, args ...string) string {
+	t.Helper()
+	var out strings.Builder
+	if err := run(args, strings.NewReader(stdin), &out); err != nil {
+		t.Fatalf("metaphone %s: %v", strings.Join(args, " "), err)
+	}
+	return out.String()
+}
+
+func TestUsage(t *testing.T) {
+	if got := runCmd(t, "", "help"); !strings.Contains(got, "golden") {
+		t.Errorf("got: %s;  want a list of commands", got)
+	}
+	if err := run([]string{"nosuch"}, nil, &strings.Builder{}); err == nil {
+		t.Errorf("unknown command got nil error")
+	}
+}
+
+func TestGolden(t *testing.T) {
+	got := runCmd(t, "Aachen\nSmith\n", "golden", "-maxlen", "4")
+	want := "'AXN' 'AKN' Aachen\n'SM0' 'XMT' Smith\n"
+	if got != want {
+		t.Errorf("got: %q;  want: %q", got, want)
+	}
+	got = runCmd(t, "", "golden", "../../testInputData.txt.gz")
+	if !strings.HasPrefix(got, "'A' '' a\n'A' '' aa\n'AXN' 'AKN' Aachen\n") {
+		t.Errorf("got: %.60q...", got)
+	}
+}
+
+func TestSymbols(t *testing.T) {
+	dir := t.TempDir()
+	src := "package x\n\nfunc getColor() {}\n\nvar init_connection = 0x1F\n"
+	if err := os.WriteFile(filepath.Join(dir, "x.go"), []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got := runCmd(t, "", "symbols", "-dir", dir, "getColour")
+	want := "getColour:\n\tgetColor\t" + filepath.Join(dir, "x.go") + ":3\n"
+	if got != want {
+		t.Errorf("got: %q;  want: %q", got, want)
+	}
+	got = runCmd(t, "init_conection\n", "symbols", "-dir", dir)
+	if !strings.Contains(got, "\tinit_connection\t") {
+		t.Errorf("got: %q;  want init_connection", got)
+	}
+}
+
+func TestLink(t *testing.T) {
+	dir := t.TempDir()
+	left := filepath.Join(dir, "left.csv")
+	right := filepath.Join(dir, "right.csv")
+	if err := os.WriteFile(left, []byte(
+		"id,Name\n1,Jon Smyth\n2,Catherine Jones\n3,Bob\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(right, []byte(
+		"name,city\nAlice,Paris\nJohn Smith,Rome\nKathryn Jones,Oslo\n"),
+		0644); err != nil {
+		t.Fatal(err)
+	}
+	got := runCmd(t, "", "link", "-maxlen", "8", left, right)
+	want := "left_row,right_row,left_Name,right_name,score\n" +
+		"1,2,Jon Smyth,John Smith,0.900\n" +
+		"2,3,Catherine Jones,Kathryn Jones,0.867\n"
+	if got != want {
+		t.Errorf("got: %q;  want: %q", got, want)
+	}
+	if err := run([]string{"link", "-lcol", "nosuch", left, right}, nil,
+		&strings.Builder{}); err == nil {
+		t.Errorf("missing column got nil error")
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	contacts := "name,email,phone\n" +
+		"Jon Smyth,jon@example.com,(555) 010-2345\n" +
+		"Mary Jones,mary@example.com,\n" +
+		"John Smith,,555.010.2345\n" +
+		"Marie Jones,mjones@example.org,\n" +
+		"Jon Smith,jon@example.com,\n" +
+		"Alice Brown,,\n"
+	got := runCmd(t, contacts, "dedupe", "-maxlen", "8", "-fields",
+		"email, phone")
+	want := "cluster,name,email,phone\n" +
+		"1,Jon Smyth,jon@example.com,(555) 010-2345\n" +
+		"1,John Smith,,555.010.2345\n" +
+		"1,Jon Smith,jon@example.com,\n"
+	if got != want {
+		t.Errorf("got: %q;  want: %q", got, want)
+	}
+	got = runCmd(t, contacts, "dedupe", "-maxlen", "8")
+	if !strings.Contains(got, "2,Mary Jones,") {
+		t.Errorf("got: %q;  want Mary Jones in cluster 2", got)
+	}
+}
+
+func TestEncodeFile(t *testing.T) {
+	var words, want strings.Builder
+	for i := range 10000 {
+		w := []string{"Aachen", "Smith", "Thompson"}[i%3]
+		fmt.Fprintln(&words, w)
+		fmt.Fprintf(&want, "%s\t%s\n", w, map[string]string{
+			"Aachen": "AXN\tAKN", "Smith": "SM0\tXMT", "Thompson": "TMPS\t"}[w])
+	}
+	got := runCmd(t, words.String()+"\n", "encode-file", "-workers", "3",
+		"-progress", "0")
+	if got != want.String() {
+		t.Errorf("got: %.60q...;  want: %.60q...", got, want.String())
+	}
+	out := filepath.Join(t.TempDir(), "out.tsv")
+	runCmd(t, "Smith\n", "encode-file", "-o", out)
+	if b, err := os.ReadFile(out); err != nil || string(b) != "Smith\tSM0\tXMT\n" {
+		t.Errorf("got: %q, %v;  want: %q", b, err, "Smith\tSM0\tXMT\n")
+	}
+}
+
+func TestBench(t *testing.T) {
+	got := runCmd(t, "Aachen\nSmith\n\n", "bench", "-maxlens", "4, 6",
+		"-time", "10ms")
+	lines := strings.Split(strings.TrimSpace(got), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[0], "encodes/s") ||
+		!strings.HasPrefix(strings.TrimSpace(lines[2]), "6 ") {
+		t.Errorf("got: %q;  want a header and rows for maxlen 4 and 6", got)
+	}
+	if err := run([]string{"bench", "-maxlens", "x"}, strings.NewReader("a"),
+		&strings.Builder{}); err == nil {
+		t.Errorf("bad -maxlens got nil error")
+	}
+}
@@ -0,0 +1,141 @@
+// symbols.go - the symbols command finds identifiers in source code that
+// sound like a query.
+// Created 2026-10-16 and placed in the public domain.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charltoncr/metaphone"
+)
+
+// skipDirs are directories symbols does not search.
+var skipDirs = map[string]bool{".git": true, ".hg": true, ".svn": true,
+	"node_modules": true, "vendor": true}
+
+// runSymbols indexes the identifiers in the source files under a
+// directory and prints those that sound like each query, with the file
+// and line where each first appears, closest spelling first.  Queries are
+// args, or the lines of stdin if there are no args.
+func runSymbols(args []string, stdin io.Reader, stdout io.Writer) error {
+	fset := newFlagSet("symbols", "[query ...]")
+	dir := fset.String("dir", ".", "directory to search")
+	exts := fset.String("ext",
+		".go,.c,.h,.cc,.cpp,.hpp,.java,.js,.ts,.py,.rb,.rs,.cs,.swift,.kt",
+		"comma-separated file name extensions to index")
+	maxLen := fset.Int("maxlen", 8, "maximum code length")
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+	locs, err := indexSymbols(*dir, strings.Split(*exts, ","))
+	if err != nil {
+		return err
+	}
+	b := metaphone.NewBuilder().MaxLen(*maxLen).MinLen(3).
+		Normalizer(metaphone.NormalizeIdentifier)
+	for sym := range locs {
+		b.Words(sym)
+	}
+	metaph, err := b.Build()
+	if err != nil {
+		return err
+	}
+	answer := func(query string) {
+		fmt.Fprintf(stdout, "%s:\n", query)
+		for _, sym := range metaph.MatchWordSorted(query, metaphone.SortDistance) {
+			fmt.Fprintf(stdout, "\t%s\t%s\n", sym, locs[sym])
+		}
+	}
+	if queries := fset.Args(); len(queries) > 0 {
+		for _, q := range queries {
+			answer(q)
+		}
+		return nil
+	}
+	sc := bufio.NewScanner(stdin)
+	for sc.Scan() {
+		if q := strings.TrimSpace(sc.Text()); len(q) > 0 {
+			answer(q)
+		}
+	}
+	return sc.Err()
+}
+
+// indexSymbols returns the identifiers in the files under dir whose names
+// end with one of exts, each with the "file:line" where it first appears.
+func indexSymbols(dir string, exts []string) (map[string]string, error) {
+	locs := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry,
+		err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && skipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		for _, ext := range exts {
+			if len(ext) > 0 && strings.HasSuffix(path, ext) {
+				return scanSymbols(path, locs)
+			}
+		}
+		return nil
+	})
+	return locs, err
+}
+
+// scanSymbols adds the identifiers of file path to locs.  Identifiers are
+// runs of letters, digits and underscores that start with a letter or
+// underscore.
+func scanSymbols(path string, locs map[string]string) error {
+	fp, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+	sc := bufio.NewScanner(fp)
+	sc.Buffer(nil, 1<<20)
+	for line := 1; sc.Scan(); line++ {
+		for _, sym := range identifiers(sc.Text()) {
+			if _, ok := locs[sym]; !ok {
+				locs[sym] = fmt.Sprintf("%s:%d", path, line)
+			}
+		}
+	}
+	if err = sc.Err(); err != nil {
+		err = fmt.Errorf("trying to read %s: %v", path, err)
+	}
+	return err
+}
+
+// identifiers returns the identifiers in s.
+func identifiers(s string) (ids []string) {
+	isLetter := func(c byte) bool {
+		return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+	}
+	isDigit := func(c byte) bool { return '0' <= c && c <= '9' }
+	for i := 0; i < len(s); {
+		if !isLetter(s[i]) && !isDigit(s[i]) {
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(s) && (isLetter(s[j]) || isDigit(s[j])) {
+			j++
+		}
+		if isLetter(s[i]) { // not a number such as 0x1F
+			ids = append(ids, s[i:j])
+		}
+		i = j
+	}
+	return
+}
@@ -0,0 +1,49 @@
+// company.go - normalize company names for vendor deduplication.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"strings"
+	"unicode"
+)
+
+// legalSuffixes holds upper-cased legal-form suffixes of company names,
+// without periods.
+var legalSuffixes = map[string]bool{
+	"INC": true, "INCORPORATED": true, "CORP": true, "CORPORATION": true,
+	"CO": true, "COMPANY": true, "LLC": true, "LLP": true, "LP": true,
+	"LTD": true, "LIMITED": true, "PLC": true, "PTY": true, "GMBH": true,
+	"AG": true, "KG": true, "SA": true, "SARL": true, "SAS": true,
+	"SPA": true, "SRL": true, "BV": true, "NV": true, "OY": true,
+	"AB": true,
+}
+
+// ampersands replaces the ways of writing "and" in company names with
+// " and ".
+var ampersands = strings.NewReplacer("&amp;", " and ", "&", " and ",
+	"＆", " and ", "+", " and ")
+
+// NormalizeCompany is a Normalizer for company names, for vendor
+// deduplication.  It spells out ampersands as "and", removes periods and
+// commas, and removes legal-form suffixes such as Inc, LLC, GmbH and Ltd
+// from the end of the name, so "Smith & Sons, L.L.C." becomes
+// "Smith and Sons" and "Müller GmbH & Co. KG" becomes "Müller".  A name
+// that is only a legal suffix is left alone.
+func NormalizeCompany(name string) string {
+	words := strings.FieldsFunc(ampersands.Replace(name), func(r rune) bool {
+		return unicode.IsSpace(r) || r == ','
+	})
+	for i, w := range words {
+		words[i] = strings.ReplaceAll(w, ".", "")
+	}
+	n := len(words)
+	for n > 1 {
+		w := strings.ToUpper(words[n-1])
+		if !legalSuffixes[w] && (w != "AND" || n == len(words)) {
+			break
+		}
+		n--
+	}
+	return strings.Join(words[:n], " ")
+}
@@ -0,0 +1,35 @@
+// company_test.go - test company.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNormalizeCompany(t *testing.T) {
+	tests := []struct{ in, out string }{
+		{"Smith & Sons LLC", "Smith and Sons"},
+		{"Smith and Sons", "Smith and Sons"},
+		{"Acme, Inc.", "Acme"},
+		{"Siemens AG", "Siemens"},
+		{"Müller GmbH & Co. KG", "Müller"},
+		{"Tata Consultancy Services Ltd.", "Tata Consultancy Services"},
+		{"Johnson+Johnson", "Johnson and Johnson"},
+		{"Ben &amp; Jerry's", "Ben and Jerry's"},
+		{"AT&T Corp", "AT and T"},
+		{"Inc.", "Inc"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeCompany(tt.in); got != tt.out {
+			t.Errorf("%q got: %q;  want: %q", tt.in, got, tt.out)
+		}
+	}
+	metaph, _ := NewBuilder().Normalizer(NormalizeCompany).MaxLen(12).
+		Words("Smith & Sons LLC", "Smyth Brothers Inc").Build()
+	want := "[Smith & Sons LLC]"
+	if got := fmt.Sprint(metaph.MatchWord("Smith and Sons")); got != want {
+		t.Errorf("got: %s;  want: %s", got, want)
+	}
+}
@@ -0,0 +1,95 @@
+// compare.go - compare words by their DoubleMetaphone codes.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import "strings"
+
+// Strength tells how strongly the DoubleMetaphone codes of two words match.
+type Strength int
+
+const (
+	// NoMatch means no code of one word matches a code of the other.
+	NoMatch Strength = iota
+	// Weak means only the secondary codes match.
+	Weak
+	// Normal means one word's primary code matches the other's secondary.
+	Normal
+	// Strong means the primary codes match.
+	Strong
+)
+
+// String returns the name of s.
+func (s Strength) String() string {
+	switch s {
+	case Weak:
+		return "Weak"
+	case Normal:
+		return "Normal"
+	case Strong:
+		return "Strong"
+	}
+	return "NoMatch"
+}
+
+// Compare returns how strongly word1 and word2 sound alike, judged by their
+// DoubleMetaphone codes of at most maxLen characters.
+func Compare(word1, word2 string, maxLen int) Strength {
+	m, m2 := DoubleMetaphone(word1, maxLen)
+	n, n2 := DoubleMetaphone(word2, maxLen)
+	return compareCodes(m, m2, n, n2)
+}
+
+// MatchAny returns the candidates that sound like word, in the order of
+// candidates, judged by DoubleMetaphone codes of at most maxLen
+// characters.  It suits small one-time comparisons for which making a
+// MetaphMap is not worthwhile.
+func MatchAny(word string, candidates []string, maxLen int) (output []string) {
+	m, m2 := DoubleMetaphone(word, maxLen)
+	for _, c := range candidates {
+		n, n2 := DoubleMetaphone(c, maxLen)
+		if compareCodes(m, m2, n, n2) != NoMatch {
+			output = append(output, c)
+		}
+	}
+	return
+}
+
+// compareCodes returns the Strength of the match between codes m, m2 of
+// one word and codes n, n2 of another.
+func compareCodes(m, m2, n, n2 string) Strength {
+	switch {
+	case len(m) > 0 && m == n:
+		return Strong
+	case len(m) > 0 && m == n2, len(n) > 0 && m2 == n:
+		return Normal
+	case len(m2) > 0 && m2 == n2:
+		return Weak
+	}
+	return NoMatch
+}
+
+// EditDistance returns the Levenshtein distance between a and b: the
+// number of single-rune insertions, deletions and substitutions needed to
+// change one into the other.  Case is ignored.
+func EditDistance(a, b string) int {
+	ra := []rune(strings.ToUpper(a))
+	rb := []rune(strings.ToUpper(b))
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,32 @@
+// compare_test.go - test compare.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want Strength
+	}{
+		{"Smith", "Smyth", Strong},
+		{"Smoot", "Schmidt", Normal},
+		{"Smith", "Jones", NoMatch},
+	}
+	for _, tt := range tests {
+		if got := Compare(tt.a, tt.b, 4); got != tt.want {
+			t.Errorf("Compare(%q, %q) got: %v;  want: %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestMatchAny(t *testing.T) {
+	got := MatchAny("knewmoanya", []string{"pneumonia", "ammonia", "neumonia"}, 4)
+	if want := "[pneumonia neumonia]"; fmt.Sprint(got) != want {
+		t.Errorf("got: %v;  want: %s", got, want)
+	}
+}
@@ -0,0 +1,158 @@
+// confusable.go - fold look-alike characters for impersonation checks.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// confusables maps characters of other scripts that look like Latin
+// letters to those letters.
+var confusables = func() map[rune]rune {
+	m := make(map[rune]rune)
+	for _, pairs := range []string{
+		// Cyrillic
+		"аaАAВBеeЕEкKКKмMМMнHНHоoОOрpРPсcСCтTТTуyУYхxХXѕsЅSіiІIјjЈJ",
+		"ԁdһhԛqԝw",
+		// Greek
+		"αaΑAβBΒBεeΕEηnΗHιiΙIκkΚKνvΝNοoΟOρpΡPτtΤTυuΥYχxΧXζZΖZμuΜM",
+		// others that look like l, o and i
+		"ǀlℓlıiɩi",
+	} {
+		runes := []rune(pairs)
+		for i := 0; i+1 < len(runes); i += 2 {
+			m[runes[i]] = runes[i+1]
+		}
+	}
+	return m
+}()
+
+// FoldConfusables is a Normalizer that replaces characters that look like
+// Latin letters with those letters, so an impersonating handle that looks
+// like a genuine one also sounds like it, e.g. "раураl" in Cyrillic
+// letters and "PaypaI" with a capital I for the l both become "paypal"
+// before phonetic matching.  It folds
+//
+//   - Cyrillic and Greek look-alikes, such as Cyrillic а and Greek ο,
+//   - fullwidth Latin letters and digits, such as Ｐ,
+//   - 1 and | next to a letter, to l, and 0 next to a letter, to o, and
+//   - capital I after a lower-case letter, to l,
+//
+// and lower-cases the result.  Apply it to both the handles of a
+// MetaphMap and queries, as Options.Normalizers are.
+func FoldConfusables(word string) string {
+	runes := []rune(word)
+	for i, r := range runes {
+		if r >= 0xFF01 && r <= 0xFF5E { // fullwidth ASCII
+			r -= 0xFEE0
+		}
+		if c, ok := confusables[r]; ok {
+			r = c
+		}
+		runes[i] = r
+	}
+	isLetter := func(i int) bool {
+		return i >= 0 && i < len(runes) && unicode.IsLetter(runes[i])
+	}
+	for i, r := range runes {
+		switch {
+		case (r == '1' || r == '|') && (isLetter(i-1) || isLetter(i+1)):
+			runes[i] = 'l'
+		case r == '0' && (isLetter(i-1) || isLetter(i+1)):
+			runes[i] = 'o'
+		case r == 'I' && i > 0 && unicode.IsLower(runes[i-1]):
+			runes[i] = 'l'
+		}
+	}
+	return strings.ToLower(string(runes))
+}
+
+// leet maps the digits and symbols of leet-speak to the letters they
+// stand for.
+var leet = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b',
+	'9': 'g', '@': 'a', '$': 's', '!': 'i', '|': 'l', '+': 't', '€': 'e',
+	'£': 'l', '¥': 'y',
+}
+
+// NormalizeLeet is a Normalizer that undoes common obfuscations of words,
+// so moderation can match disguised banned terms by sound:
+//
+//   - in a word with letters, leet-speak digits and symbols become the
+//     letters they stand for: 3 and € are E, 4 and @ are A, $ and 5 are
+//     S, 0 is O, 1 and ! are I, 7 and + are T, and so on, so "h4x0r" and
+//     "$h1t" become "haxor" and "shit";
+//   - runs of three or more of a letter become one, so "baaaad" becomes
+//     "bad";
+//   - letters spaced out with spaces, dots, hyphens or underscores are
+//     joined, so "f.r.e.e" and "f r e e" become "free".
+//
+// The result is lower-cased.  Words without letters, such as "1984", are
+// left as they are.  Combine it with FoldConfusables, applied after it,
+// for look-alike letters of other scripts; applied before it,
+// FoldConfusables would read the 1 of "fr33b13" as l instead of i.
+func NormalizeLeet(word string) string {
+	word = joinSpacedLetters(word)
+	fields := strings.Fields(word)
+	for i, f := range fields {
+		if !strings.ContainsFunc(f, unicode.IsLetter) {
+			continue
+		}
+		var b strings.Builder
+		var last rune
+		run := 0
+		for _, r := range strings.ToLower(f) {
+			if l, ok := leet[r]; ok {
+				r = l
+			}
+			if r == last && unicode.IsLetter(r) {
+				run++
+			} else {
+				last, run = r, 1
+			}
+			if run < 3 {
+				b.WriteRune(r)
+			} else if run == 3 {
+				// a run of three or more is one letter
+				s := b.String()
+				b.Reset()
+				b.WriteString(s[:len(s)-utf8.RuneLen(r)])
+			}
+		}
+		fields[i] = b.String()
+	}
+	return strings.Join(fields, " ")
+}
+
+// joinSpacedLetters joins letters separated by single spaces, dots,
+// hyphens or underscores, as in "f r e e" or "f.r.e.e", when at least
+// three are spaced out so.
+func joinSpacedLetters(s string) string {
+	isSep := func(r rune) bool {
+		return r == ' ' || r == '.' || r == '-' || r == '_'
+	}
+	runes := []rune(s)
+	var out []rune
+	for i := 0; i < len(runes); {
+		// find a run of single characters separated by single separators
+		j := i
+		for j+2 < len(runes) && !isSep(runes[j]) && isSep(runes[j+1]) &&
+			!isSep(runes[j+2]) && (j == 0 || isSep(runes[j-1])) &&
+			(j+3 >= len(runes) || isSep(runes[j+3])) {
+			j += 2
+		}
+		if n := (j-i)/2 + 1; j > i && n >= 3 && (i == 0 || isSep(runes[i-1])) {
+			for k := i; k <= j; k += 2 {
+				out = append(out, runes[k])
+			}
+			i = j + 1
+			continue
+		}
+		out = append(out, runes[i])
+		i++
+	}
+	return string(out)
+}
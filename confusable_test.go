@@ -0,0 +1,51 @@
+// confusable_test.go - test confusable.go.
+// This file is public domain.
+
+package metaphone
+
+import "testing"
+
+func TestFoldConfusables(t *testing.T) {
+	tests := []struct{ in, out string }{
+		{"раураl", "paypal"}, // Cyrillic р, а, у
+		{"PaypaI", "paypal"},
+		{"paypa1", "paypal"},
+		{"g00gle", "google"},
+		{"Ｐａｙｐａｌ", "paypal"},
+		{"αpple", "apple"},
+		{"Ian 1984", "ian 1984"},
+		{"IBM", "ibm"},
+	}
+	for _, tt := range tests {
+		if got := FoldConfusables(tt.in); got != tt.out {
+			t.Errorf("%q got: %q;  want: %q", tt.in, got, tt.out)
+		}
+	}
+	metaph, _ := NewBuilder().Normalizer(FoldConfusables).
+		Words("PayPal", "Google").Build()
+	for _, q := range []string{"раураl", "PaypaI", "g00g1e"} {
+		if got := metaph.MatchWord(q); len(got) != 1 {
+			t.Errorf("%s got: %v;  want one match", q, got)
+		}
+	}
+}
+
+func TestNormalizeLeet(t *testing.T) {
+	tests := []struct{ in, out string }{
+		{"h4x0r", "haxor"},
+		{"$h1t", "shit"},
+		{"fr33", "free"},
+		{"baaaad", "bad"},
+		{"b@@@d", "bad"},
+		{"f.r.e.e", "free"},
+		{"f r e e stuff", "free stuff"},
+		{"Good day", "good day"},
+		{"1984 B.C.", "1984 b.c."},
+		{"a b", "a b"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeLeet(tt.in); got != tt.out {
+			t.Errorf("%q got: %q;  want: %q", tt.in, got, tt.out)
+		}
+	}
+}
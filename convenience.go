@@ -6,21 +6,97 @@
 package metaphone
 
 import (
-	"compress/gzip"
-	"fmt"
-	"io"
-	"os"
 	"strings"
+	"sync"
+	"unicode/utf8"
 )
 
 // MetaphMap defines a MetaphMap for a wordlist and maximum metaph/metaph2
 // length from DoubleMetaphone.
+// A nil *MetaphMap is an empty map: its lookups and statistics find
+// nothing, and Add, RemoveWord, RemoveWhere and SetFrequency change
+// nothing.  Its set operations and WriteIndex return an error.
 type MetaphMap struct {
 	mapper map[string][]string
 	// maximum length of metaph and metaph2 in DoubleMetaphone.
 	maxlen int
+	// options used to make the map; also applied to queries.
+	opts Options
+	// upper-cased Options.StopWords.
+	stop map[string]bool
+	// lower-cased word to stored word, for CaseLower and CaseFold.
+	canon map[string]string
+	// encodes words and queries.
+	enc *Encoder
+	// stored word to the number of times it was added, or as set by
+	// SetFrequency.
+	freq map[string]int
+	// stored word to the metadata it was added with by Add.
+	meta map[string][]Metadata
+	// stored word to its codes, if Options.ReverseIndex is set.
+	codes map[string][2]string
+	// the codes of mapper sorted, for MatchCodePrefix, or nil if they
+	// changed since they were sorted.
+	sorted []string
+	// the stored words sorted by their lower-cased form, for
+	// Autocomplete, or nil if they changed since they were sorted.
+	sortedWords []wordKey
+	sortedMu    sync.Mutex
 }
 
+// Options holds settings for making a MetaphMap.  The zero value makes a
+// MetaphMap like the one NewMetaphMap makes.
+type Options struct {
+	// Normalizers are applied in order to each word before it is encoded,
+	// both when a MetaphMap is made and when it is queried.  They change
+	// only the codes a word is stored under; MatchWord still returns
+	// words as they appear in the word list.
+	Normalizers []Normalizer
+	// Rhyme makes a MetaphMap that finds rhymes instead of sound-alikes.
+	// See NewRhymeMap.
+	Rhyme bool
+	// StopWords are words left out of a MetaphMap.  Case is ignored.
+	StopWords []string
+	// MinLen is the number of characters a word needs to be put in a
+	// MetaphMap.  Short words otherwise make large, useless buckets.
+	MinLen int
+	// Case tells how the case of words is stored and returned.
+	Case CasePolicy
+	// Encoder, if not nil, encodes words and queries after they are
+	// normalized, so its settings, such as its treatment of apostrophes
+	// and hyphens, apply to both.  Its MaxLen is replaced by the
+	// MetaphMap's maxLen.
+	Encoder *Encoder
+	// ReverseIndex keeps the codes of each word, so that RemoveWord finds
+	// the buckets of a word directly instead of scanning them all, at the
+	// cost of memory for an entry per word.
+	ReverseIndex bool
+	// Charset is the character encoding of word list files.  Word lists
+	// are read as UTF-8 by default; CharsetAuto detects legacy encodings.
+	// A byte order mark, or a declaration in a word list's first line
+	// such as "# encoding: latin-1", overrides Charset; either is removed.
+	Charset Charset
+	// StrictWordlist makes a word list file with a CRLF line ending,
+	// leading or trailing whitespace or a blank line an error that wraps
+	// ErrDictionaryFormat.  Otherwise such line endings and whitespace
+	// are removed from words and blank lines are skipped.
+	StrictWordlist bool
+}
+
+// CasePolicy tells how a MetaphMap stores the case of its words.
+type CasePolicy int
+
+const (
+	// CaseOriginal stores each word as it appears in the word list, so
+	// "Apple" and "apple" are both stored and returned.
+	CaseOriginal CasePolicy = iota
+	// CaseLower stores each word lower-cased, once.
+	CaseLower
+	// CaseFold stores words that differ only in case once, in the form
+	// in which the first of them appears in the word list.
+	CaseFold
+)
+
 // NewMetaphMap returns a MetaphMap made from wordlist and a maximum
 // length for the DoubleMetaphone return values.
 // The MetaphMap can be used with MatchWord to find all words in the
@@ -29,58 +105,182 @@ type MetaphMap struct {
 // Case is ignored in the words in wordlist, as are non-alphabetic
 // characters.
 func NewMetaphMap(wordlist []string, maxLen int) *MetaphMap {
-	MMap := make(map[string][]string)
+	return NewMetaphMapWithOptions(wordlist, maxLen, nil)
+}
+
+// NewMetaphMapWithOptions is like NewMetaphMap but makes the MetaphMap
+// with opts, which can be nil.
+//
+// Deprecated: Use NewBuilder, which sets the same options one at a time.
+func NewMetaphMapWithOptions(wordlist []string, maxLen int,
+	opts *Options) *MetaphMap {
+	metaph := newMetaphMap(maxLen, opts)
 	for _, word := range wordlist {
-		m, m2 := DoubleMetaphone(word, maxLen)
-		if len(m) > 0 {
-			MMap[m] = append(MMap[m], word)
-		}
-		if len(m2) > 0 {
-			MMap[m2] = append(MMap[m2], word)
-		}
+		metaph.add(word)
 	}
-	return &MetaphMap{
-		mapper: MMap,
+	return metaph
+}
+
+// newMetaphMap returns an empty MetaphMap with maxLen and opts, which can
+// be nil.
+func newMetaphMap(maxLen int, opts *Options) *MetaphMap {
+	metaph := &MetaphMap{
+		mapper: make(map[string][]string),
 		maxlen: maxLen,
+		freq:   make(map[string]int),
 	}
+	if opts != nil {
+		metaph.opts = *opts
+	}
+	metaph.enc = &Encoder{}
+	if metaph.opts.Encoder != nil {
+		*metaph.enc = *metaph.opts.Encoder
+	}
+	metaph.enc.MaxLen = maxLen
+	if metaph.opts.Case != CaseOriginal {
+		metaph.canon = make(map[string]string)
+	}
+	if metaph.opts.ReverseIndex {
+		metaph.codes = make(map[string][2]string)
+	}
+	if len(metaph.opts.StopWords) > 0 {
+		metaph.stop = make(map[string]bool)
+		for _, w := range metaph.opts.StopWords {
+			metaph.stop[strings.ToUpper(w)] = true
+		}
+	}
+	return metaph
 }
 
 // NewMetaphMapFromFile returns a MetaphMap made from a file containing a
 // word list, and using a maximum length for the DoubleMetaphone return values.
-// The file can be a gzipped file with its name ending with ".gz".
+// The file can be a gzipped file with its name ending with ".gz", or a zip
+// archive with its name ending with ".zip", whose files, which can also be
+// gzipped, are all read; Builder's FromZip selects some of them.
 // The MetaphMap can be used with MatchWord to find all words in the
 // MetaphMap that sound like a given word or misspelling.
 // Argument maxLen is 4 in the original Double Metaphone algorithm.
 // Case and non-alphabetic characters in the file are ignored.
 func NewMetaphMapFromFile(fileName string, maxLen int) (
 	metaph *MetaphMap, err error) {
-	var b []byte
-	var r io.Reader
-	var fp *os.File
+	return NewMetaphMapFromFileWithOptions(fileName, maxLen, nil)
+}
+
+// NewMetaphMapFromFileWithOptions is like NewMetaphMapFromFile but makes
+// the MetaphMap with opts, which can be nil.  If opts.Encoder.Strict is
+// true, a word with a character that EncodeStrict rejects is an error
+// that wraps ErrDictionaryFormat and ErrUnsupportedChar.
+//
+// Deprecated: Use NewBuilder with FromFile.
+func NewMetaphMapFromFileWithOptions(fileName string, maxLen int,
+	opts *Options) (metaph *MetaphMap, err error) {
+	var words []string
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	if words, err = readWordlistFile(fileName, o); err != nil {
+		return
+	}
+	return NewMetaphMapWithOptions(words, maxLen, opts), err
+}
+
+// readWordlistFile returns the words of a word list file, which can be a
+// gzipped file with its name ending with ".gz", or a zip archive with its
+// name ending with ".zip", whose word list files are all read, in the
+// character encoding and checked per opts, as splitWordlist returns them.
+func readWordlistFile(fileName string, opts Options) (words []string,
+	err error) {
+	return readSource(FileSource(fileName), nil, opts)
+}
+
+// add adds word to metaph under each of its codes, unless it is a stop
+// word, is too short, or is already stored per metaph's CasePolicy.  It
+// returns the word as stored and true, or "" and false if word was left
+// out.
+func (metaph *MetaphMap) add(word string) (stored string, ok bool) {
+	word, isNew, ok := metaph.admit(word)
+	if !isNew {
+		return word, ok
+	}
+	m, m2 := metaph.encode(word)
+	metaph.sorted, metaph.sortedWords = nil, nil
+	if len(m) > 0 {
+		metaph.mapper[m] = append(metaph.mapper[m], word)
+	}
+	if len(m2) > 0 {
+		metaph.mapper[m2] = append(metaph.mapper[m2], word)
+	}
+	if metaph.codes != nil {
+		metaph.codes[word] = [2]string{m, m2}
+	}
+	return word, true
+}
 
-	if fp, err = os.Open(fileName); err != nil {
-		err = fmt.Errorf("trying to open file %s: %v", fileName, err)
+// admit counts word in metaph's frequencies unless it is a stop word or
+// is too short.  It returns the word as it is to be stored, whether it is
+// to be added under its codes, which it is not if it is already stored
+// per metaph's CasePolicy, and whether it was admitted.
+func (metaph *MetaphMap) admit(word string) (stored string, isNew, ok bool) {
+	if utf8.RuneCountInString(word) < metaph.opts.MinLen ||
+		metaph.stop != nil && metaph.stop[strings.ToUpper(word)] {
 		return
 	}
-	defer fp.Close()
-	r = fp
-	if strings.HasSuffix(fileName, ".gz") {
-		if r, err = gzip.NewReader(r); err != nil {
-			err = fmt.Errorf(
-				"trying to make a gzip reader for file %s: %v", fileName, err)
-			return
+	if metaph.canon != nil {
+		lower := strings.ToLower(word)
+		if w, ok := metaph.canon[lower]; ok {
+			metaph.freq[w]++
+			return w, false, true
+		}
+		if metaph.opts.Case == CaseLower {
+			word = lower
 		}
+		metaph.canon[lower] = word
 	}
-	if b, err = io.ReadAll(r); err != nil {
-		err = fmt.Errorf("trying to read file %s: %v", fileName, err)
+	metaph.freq[word]++
+	return word, true, true
+}
+
+// Normalize returns word as metaph's normalizers change it before it is
+// encoded.  Words of the word list and queries are normalized alike, so
+// a caller need not clean up queries itself: with FoldAccents among the
+// normalizers, a query "Angstrom" finds a word "Ångström" and a query
+// "Ångström" finds "Angstrom".
+func (metaph *MetaphMap) Normalize(word string) string {
+	if metaph == nil {
+		return word
+	}
+	for _, normalize := range metaph.opts.Normalizers {
+		word = normalize(word)
+	}
+	return word
+}
+
+// Codes returns the codes metaph stores word under, which are also those
+// MatchWord looks word up by: its DoubleMetaphone codes, or rhyme codes,
+// after Normalize, from metaph's Encoder.
+func (metaph *MetaphMap) Codes(word string) (m, m2 string) {
+	if metaph == nil {
 		return
 	}
-	lines := strings.Split(string(b), "\n")
-	return NewMetaphMap(lines, maxLen), err
+	return metaph.encode(word)
+}
+
+// encode returns the DoubleMetaphone codes of word after applying
+// metaph's normalizers to it.
+func (metaph *MetaphMap) encode(word string) (m, m2 string) {
+	word = metaph.Normalize(word)
+	if metaph.opts.Rhyme {
+		return rhymeCodes(word)
+	}
+	return metaph.enc.Encode(word)
 }
 
 // Len returns the number of sound-alike entries in metaph.
 func (metaph *MetaphMap) Len() int {
+	if metaph == nil {
+		return 0
+	}
 	return len(metaph.mapper)
 }
 
@@ -98,14 +298,20 @@ func (metaph *MetaphMap) Len() int {
 //			fmt.Println(word)
 //		}
 func (metaph *MetaphMap) MatchWord(word string) (output []string) {
-	m, m2 := DoubleMetaphone(word, metaph.maxlen)
+	if metaph == nil {
+		return
+	}
+	m, m2 := metaph.encode(word)
 	if len(m) > 0 {
-		output = metaph.mapper[m]
+		// copied, so appending never writes to the bucket's spare room,
+		// which concurrent readers share
+		output = append(output, metaph.mapper[m]...)
 	}
 	if len(m2) > 0 {
 		output = append(output, metaph.mapper[m2]...)
 	}
 	output = removeDups(output)
+	metaph.enc.Hooks.match(word, output)
 	return
 }
 
@@ -0,0 +1,92 @@
+// corpus.go - prepare large corpora for encoding.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+// corpusClass classifies each byte for PreprocessCorpus: corpusSep
+// separates words, corpusKeep is part of a word as it is, corpusApos is an
+// apostrophe, and any other value is part of a word and is subtracted
+// from it to upper-case it.
+var corpusClass = func() (class [256]byte) {
+	for c := range 256 {
+		switch {
+		case c >= 'a' && c <= 'z':
+			class[c] = 'a' - 'A'
+		case c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c >= 0x80:
+			class[c] = corpusKeep
+		case c == '\'':
+			class[c] = corpusApos
+		default:
+			class[c] = corpusSep
+		}
+	}
+	return
+}()
+
+const (
+	corpusKeep = 0
+	corpusApos = 0xfe
+	corpusSep  = 0xff
+)
+
+// PreprocessCorpus upper-cases the ASCII letters of corpus in place and
+// returns its words: runs of ASCII letters and digits and of bytes of
+// non-ASCII characters, which are left for the Encoder to upper-case.
+// Spaces, punctuation and ASCII control characters separate words, except
+// that an apostrophe between two characters of a word is kept, as the
+// Encoder's Apostrophes policy expects, so "O'Brien" and "don't" are
+// single words.
+//
+// It makes a single pass over corpus with one table lookup per byte that
+// both classifies and upper-cases it, a loop the compiler keeps tight,
+// and the returned words share one copy of corpus, so a corpus of
+// millions of words costs a few allocations instead of one per word.  The
+// words can be given to (*Builder).Bulk and Words or encoded with
+// EncodeCorpus.
+func PreprocessCorpus(corpus []byte) (words []string) {
+	var spans []int
+	start := -1
+	for i, c := range corpus {
+		class := corpusClass[c]
+		if class == corpusApos {
+			// part of the word only if a word character follows
+			if start >= 0 && i+1 < len(corpus) &&
+				corpusClass[corpus[i+1]] < corpusApos {
+				continue
+			}
+			class = corpusSep
+		}
+		if class == corpusSep {
+			if start >= 0 {
+				spans = append(spans, start, i)
+				start = -1
+			}
+			continue
+		}
+		corpus[i] = c - class
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, start, len(corpus))
+	}
+	text := string(corpus)
+	words = make([]string, 0, len(spans)/2)
+	for i := 0; i < len(spans); i += 2 {
+		words = append(words, text[spans[i]:spans[i+1]])
+	}
+	return
+}
+
+// EncodeCorpus preprocesses corpus with PreprocessCorpus and calls fn with
+// each word and its codes, in order.  res is reused for every word, so fn
+// must copy its codes to keep them.
+func (enc *Encoder) EncodeCorpus(corpus []byte, fn func(word string,
+	res *Result)) {
+	var res Result
+	for _, word := range PreprocessCorpus(corpus) {
+		enc.EncodeInto(word, &res)
+		fn(word, &res)
+	}
+}
@@ -0,0 +1,50 @@
+// corpus_test.go - test corpus.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestPreprocessCorpus(t *testing.T) {
+	corpus := []byte("The knight's 2 Müller-Smiths,\nnight\tAT noon.")
+	got := PreprocessCorpus(corpus)
+	want := "[THE KNIGHT'S 2 MüLLER SMITHS NIGHT AT NOON]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("got: %v;  want: %s", got, want)
+	}
+	got = PreprocessCorpus([]byte("O'Brien don't 'quoted' rock'n'roll a '' b'"))
+	want = "[O'BRIEN DON'T QUOTED ROCK'N'ROLL A B]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("got: %v;  want: %s", got, want)
+	}
+	if got := PreprocessCorpus([]byte(" ,. ")); len(got) != 0 {
+		t.Errorf("separators only got: %v", got)
+	}
+}
+
+func TestEncodeCorpus(t *testing.T) {
+	enc := &Encoder{MaxLen: 6}
+	var got []string
+	enc.EncodeCorpus([]byte("Smith, knight"), func(word string, res *Result) {
+		got = append(got, fmt.Sprintf("%s %s %s", word, res.Metaph, res.Metaph2))
+	})
+	want := "[SMITH SM0 XMT KNIGHT NT ]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("got: %v;  want: %s", got, want)
+	}
+}
+
+func BenchmarkPreprocessCorpus(b *testing.B) {
+	corpus := bytes.Repeat([]byte("The quick brown fox, jumping over lazy dogs.\n"), 10000)
+	buf := make([]byte, len(corpus))
+	b.SetBytes(int64(len(corpus)))
+	b.ResetTimer()
+	for range b.N {
+		copy(buf, corpus)
+		PreprocessCorpus(buf)
+	}
+}
@@ -0,0 +1,62 @@
+// dedupe.go - group sound-alike entries of a list.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import "strings"
+
+// DeduplicateFuzzy groups the items that sound alike, so that duplicate
+// entries such as differently spelled customer names can be collapsed.
+// It is DeduplicateFuzzyDist with a maxLen of 4 and no edit distance
+// limit.
+func DeduplicateFuzzy(items []string) [][]string {
+	return DeduplicateFuzzyDist(items, 4, -1)
+}
+
+// DeduplicateFuzzyDist groups the items that sound alike.  Each group
+// starts with the first item of its kind; a later item joins the earliest
+// group whose first item shares a DoubleMetaphone code of at most maxLen
+// characters with it and, if maxDist >= 0, is within maxDist edits of it
+// (see EditDistance).  Items equal but for case are always grouped
+// together.  Groups, and the items in each group, are in the order of
+// items.
+func DeduplicateFuzzyDist(items []string, maxLen, maxDist int) (
+	groups [][]string) {
+	byCode := make(map[string][]int)
+	byWord := make(map[string]int)
+	for _, item := range items {
+		key := strings.ToUpper(item)
+		if g, ok := byWord[key]; ok {
+			groups[g] = append(groups[g], item)
+			continue
+		}
+		m, m2 := DoubleMetaphone(item, maxLen)
+		g := -1
+		for _, c := range byCode[m] {
+			if maxDist < 0 || EditDistance(item, groups[c][0]) <= maxDist {
+				g = c
+				break
+			}
+		}
+		for _, c := range byCode[m2] {
+			if (g < 0 || c < g) &&
+				(maxDist < 0 || EditDistance(item, groups[c][0]) <= maxDist) {
+				g = c
+				break
+			}
+		}
+		if g < 0 {
+			g = len(groups)
+			groups = append(groups, nil)
+			if len(m) > 0 {
+				byCode[m] = append(byCode[m], g)
+			}
+			if len(m2) > 0 && m2 != m {
+				byCode[m2] = append(byCode[m2], g)
+			}
+		}
+		groups[g] = append(groups[g], item)
+		byWord[key] = g
+	}
+	return
+}
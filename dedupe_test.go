@@ -0,0 +1,34 @@
+// dedupe_test.go - test dedupe.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDeduplicateFuzzy(t *testing.T) {
+	items := []string{"Smith", "Jones", "Smyth", "Schmidt", "Johns", "smith", "Brown"}
+	want := "[[Smith Smyth Schmidt smith] [Jones Johns] [Brown]]"
+	if got := fmt.Sprint(DeduplicateFuzzy(items)); got != want {
+		t.Errorf("got: %s;  want: %s", got, want)
+	}
+	want = "[[Smith Smyth smith] [Jones Johns] [Schmidt] [Brown]]"
+	if got := fmt.Sprint(DeduplicateFuzzyDist(items, 4, 2)); got != want {
+		t.Errorf("got: %s;  want: %s", got, want)
+	}
+	if d := EditDistance("kitten", "SITTING"); d != 3 {
+		t.Errorf("EditDistance got: %d;  want: 3", d)
+	}
+}
+
+func TestClusters(t *testing.T) {
+	// Smith (SM0/XMT) and Schmidt (XMT/SMT) share XMT; Schmidt and Smit
+	// (SMT) share SMT, so all three form one cluster.
+	words := []string{"Brown", "Smith", "Jones", "Schmidt", "Johns", "Smit", "Smith"}
+	want := "[[Smith Schmidt Smit] [Jones Johns] [Brown]]"
+	if got := fmt.Sprint(Clusters(words, 4)); got != want {
+		t.Errorf("got: %s;  want: %s", got, want)
+	}
+}
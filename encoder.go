@@ -0,0 +1,388 @@
+// encoder.go - an Encoder holds settings for DoubleMetaphone encoding.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Encoder encodes words and phrases with DoubleMetaphone.  The zero value
+// is ready to use and encodes like DoubleMetaphone with a maxlength of 4,
+// as does a nil *Encoder.
+type Encoder struct {
+	// MaxLen is the maximum length of each code.  It is 4 if less than 1.
+	MaxLen int
+	// Version is the version of the rules used to encode words.  It is
+	// Version1, the reference algorithm, if 0.
+	Version AlgorithmVersion
+	// Apostrophes tells how apostrophes (' and ’) in words are treated.
+	Apostrophes PunctPolicy
+	// Hyphens tells how hyphens in words are treated.
+	Hyphens PunctPolicy
+	// OtherPunct tells how other punctuation, symbols and emoji in words
+	// are treated, such as the "!" in "Yahoo!" or the emoji in
+	// user-generated text.  PunctStrip and PunctSplit also make
+	// EncodeStrict accept them.
+	OtherPunct PunctPolicy
+	// Boundary, if not empty, is put between the codes of the words of
+	// a phrase, as joined by EncodePhrase and PunctSplit, so "ice land"
+	// ("AS|LNT" with a Boundary of "|") and "Iceland" ("ASLNT") can be
+	// told apart.  It counts toward MaxLen when PunctSplit joins codes.
+	Boundary string
+	// Split splits phrases and documents into words.  It is ScanWords if
+	// nil.  A custom Split can keep tokens such as "C6H12O6" or "AB-1234"
+	// whole.
+	Split bufio.SplitFunc
+	// Symbols maps code symbols to the strings that replace them in
+	// Encode's results, such as '0' (the TH sound) to "TH" or "θ".  It is
+	// applied after codes are limited to MaxLen symbols.
+	Symbols map[rune]string
+	// Width, if greater than 0, makes each nonempty code exactly Width
+	// characters long, Soundex-style, by padding it with PadChar or
+	// cutting it short.  It is applied last, after Symbols.
+	Width int
+	// PadChar pads codes shorter than Width.  It is '_' if 0.
+	PadChar rune
+	// Strict makes NewMetaphMapFromFileWithOptions fail on a word that
+	// EncodeStrict rejects, instead of ignoring the word's unsupported
+	// characters.
+	Strict bool
+	// Rules, if not nil, override the built-in DoubleMetaphone rules.
+	// See LoadRules.
+	Rules Rules
+	// Enhanced, if not EnhancedOff, applies the enhanced rules of that
+	// version after Rules and before the built-in rules, for better codes
+	// for words whose silent letters DoubleMetaphone encodes.
+	Enhanced EnhancedVersion
+	// Vowels makes codes include the vowels after a word's first letter,
+	// each run of vowels folded to one 'A', instead of dropping them, so
+	// "Rhiannon" is encoded "RANAN" rather than "RNN".  Codes become
+	// more selective: "Rene" ("RANA") and "Ron" ("RAN") no longer share
+	// the code "RN".
+	// MaxLen should be raised to make room for the vowels.
+	Vowels bool
+	// DropTrailingS makes Encode ignore a trailing plural or possessive S,
+	// so "cats", "cat's", "cats'" and "cities" are encoded as "cat" and
+	// "city" are.  Only words of four or more letters lose a plural S,
+	// and words ending in -ss, -us and -is, such as "glass" and
+	// "census", keep theirs.
+	DropTrailingS bool
+	// Digits makes Encode spell out the digits in a word, such as a
+	// product SKU or gamer tag, and encode the spoken form.  Each run of
+	// letters and each run of digits is encoded separately, as with
+	// PunctSplit, so "K9" is encoded as "K nine" and "B2B" as "B two B".
+	// A run of up to four digits that does not start with 0 is read as a
+	// number, so "2024" is "two thousand twenty four"; a longer run is
+	// read digit by digit.
+	Digits bool
+	// Acronyms makes Encode spell out acronyms, words of two or more
+	// capital letters with no vowels or that are well-known acronyms such
+	// as "FBI", and encode the names of their letters, so "SQL" is
+	// encoded as "ess cue ell" is.  Other capitals, such as "NASA" and
+	// the "SMITH" of an upper-case name list, are encoded as words.
+	Acronyms bool
+	// AutoLanguage makes Encode guess the language of each word with
+	// DetectLanguage and encode it with the encoder for that language,
+	// Koelner for German and SpanishMetaphone for Spanish, for
+	// dictionaries that mix languages.  Codes for different languages
+	// never match each other; EncodeLang instead gives DoubleMetaphone
+	// codes for a language that is known.
+	AutoLanguage bool
+	// Hooks, if not nil, observe the Encoder and the MetaphMaps made
+	// with it.
+	Hooks *Hooks
+}
+
+// PunctPolicy tells how an Encoder treats a kind of punctuation in a word,
+// such as the apostrophe in "O'Brien" or the hyphens in "mother-in-law".
+type PunctPolicy int
+
+const (
+	// PunctKeep passes the punctuation to DoubleMetaphone, which ignores
+	// it except as context for neighboring letters.  EncodeStrict rejects
+	// kept punctuation other than apostrophes and hyphens.
+	PunctKeep PunctPolicy = iota
+	// PunctStrip removes the punctuation, so "O'Brien" is encoded as
+	// "OBrien".
+	PunctStrip
+	// PunctSplit splits the word at the punctuation, encodes each part
+	// separately and joins the parts' codes, as EncodePhrase does.  The
+	// joined codes are limited to MaxLen characters.
+	PunctSplit
+)
+
+// isOtherPunct returns true if r is punctuation, a symbol or part of an
+// emoji, other than an apostrophe or hyphen: any character that is not a
+// letter, digit, combining mark or space.  Variation selectors and the
+// zero width joiner, which join emoji, are included.
+func isOtherPunct(r rune) bool {
+	switch {
+	case strings.ContainsRune("'’-‐", r):
+		return false
+	case unicode.Is(unicode.Variation_Selector, r):
+		return true
+	}
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) &&
+		!unicode.IsMark(r) && !unicode.IsSpace(r)
+}
+
+// NewEncoder returns an Encoder that makes codes of at most maxLen
+// characters.
+func NewEncoder(maxLen int) *Encoder {
+	return &Encoder{MaxLen: maxLen}
+}
+
+// Encode returns the primary and secondary codes for word, as
+// DoubleMetaphone does, after treating punctuation in word as enc
+// specifies.
+func (enc *Encoder) Encode(word string) (metaph, metaph2 string) {
+	return enc.encode(word, "")
+}
+
+// EncodeLang is like Encode but adjusts the DoubleMetaphone rules for the
+// language of BCP 47 language tag lang, such as "es" or "de-AT", so a
+// caller who knows the language gets better codes: for Spanish, J and G
+// before E or I sound like H and B and V sound alike; for German, W and V
+// sound alike and SCH sounds like SH.  The codes are DoubleMetaphone
+// codes, so words encoded for different languages can be stored in one
+// MetaphMap and matched against each other.  Words of other languages
+// are encoded with DoubleMetaphone unadjusted, even if enc.AutoLanguage
+// is true; an empty lang is the same as calling Encode.  AutoLanguage
+// instead encodes with SpanishMetaphone and Koelner, whose codes cannot
+// be compared with DoubleMetaphone codes.
+func (enc *Encoder) EncodeLang(word, lang string) (metaph, metaph2 string) {
+	return enc.encode(word, parseLanguage(lang))
+}
+
+// encode encodes word for Encode and EncodeLang.  An empty lang means the
+// language is unknown.
+func (enc *Encoder) encode(word string, lang Language) (metaph,
+	metaph2 string) {
+	if enc == nil {
+		enc = &Encoder{}
+	}
+	w := word
+	if enc.Version >= Version3 {
+		w = composeWord(w)
+	}
+	if enc.DropTrailingS {
+		w = dropTrailingS(w)
+	}
+	split := false
+	if enc.Digits && strings.ContainsAny(w, "0123456789") {
+		w, split = spellDigits(w), true
+	}
+	w = strings.Map(func(r rune) rune {
+		policy := PunctKeep
+		switch r {
+		case '\'', '’':
+			policy = enc.Apostrophes
+		case '-', '‐':
+			policy = enc.Hyphens
+		default:
+			if isOtherPunct(r) {
+				policy = enc.OtherPunct
+			}
+		}
+		switch policy {
+		case PunctStrip:
+			return -1
+		case PunctSplit:
+			split = true
+			return ' '
+		}
+		return r
+	}, w)
+	if split {
+		var parts []Codes
+		for _, part := range strings.Fields(w) {
+			m, m2 := enc.encodeWord(part, lang)
+			parts = append(parts, Codes{Word: part, Metaph: m, Metaph2: m2})
+		}
+		metaph, metaph2 = joinCodes(parts, enc.Boundary)
+		metaph, metaph2 = enc.truncate(metaph), enc.truncate(metaph2)
+	} else {
+		metaph, metaph2 = enc.encodeWord(w, lang)
+	}
+	metaph, metaph2 = enc.pad(enc.remap(metaph)), enc.pad(enc.remap(metaph2))
+	enc.Hooks.encode(word, metaph, metaph2)
+	return
+}
+
+// composeWord returns word in Unicode Normalization Form C without the
+// combining marks that are left after composition, as described for
+// Version3.
+func composeWord(word string) string {
+	if isASCII(word) {
+		return word
+	}
+	return strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+		return r
+	}, norm.NFC.String(word))
+}
+
+// isASCII returns true if s has only ASCII characters.
+func isASCII(s string) bool {
+	for i := range len(s) {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// spellDigits returns word with each run of digits spelled out and set
+// off by spaces, as described for Encoder.Digits.
+func spellDigits(word string) string {
+	var b strings.Builder
+	for len(word) > 0 {
+		i := strings.IndexAny(word, "0123456789")
+		if i < 0 {
+			b.WriteString(word)
+			break
+		}
+		b.WriteString(word[:i])
+		word = word[i:]
+		n := len(word) - len(strings.TrimLeft(word, "0123456789"))
+		run := word[:n]
+		word = word[n:]
+		b.WriteByte(' ')
+		if v, err := strconv.ParseUint(run, 10, 64); err == nil &&
+			n <= 4 && run[0] != '0' {
+			b.WriteString(numberWords(v))
+		} else {
+			for _, d := range run {
+				b.WriteString(smallNumbers[d-'0'])
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
+
+// dropTrailingS returns word without a trailing possessive 's, s' or ’s,
+// or, for a word of four or more letters, a plural -s or -ies, which
+// becomes -y.  Words ending in -ss, -us and -is, such as "glass",
+// "census" and "analysis", are not plurals and are left alone.
+func dropTrailingS(word string) string {
+	for _, suffix := range []string{"'s", "’s", "'S", "’S"} {
+		if w, ok := strings.CutSuffix(word, suffix); ok {
+			return w
+		}
+	}
+	if w, ok := strings.CutSuffix(word, "'"); ok {
+		word = w
+	} else if w, ok := strings.CutSuffix(word, "’"); ok {
+		word = w
+	}
+	upper := strings.ToUpper(word)
+	switch {
+	case len(upper) < 4 || !strings.HasSuffix(upper, "S"):
+	case strings.HasSuffix(upper, "SS"), strings.HasSuffix(upper, "US"),
+		strings.HasSuffix(upper, "IS"):
+	case strings.HasSuffix(upper, "IES"):
+		word = word[:len(word)-3] + "y"
+	default:
+		word = word[:len(word)-1]
+	}
+	return word
+}
+
+// pad returns code padded or cut to enc.Width characters, if enc.Width is
+// greater than 0 and code is not empty.
+func (enc *Encoder) pad(code string) string {
+	if enc.Width < 1 || len(code) == 0 {
+		return code
+	}
+	r := []rune(code)
+	if len(r) >= enc.Width {
+		return string(r[:enc.Width])
+	}
+	padChar := enc.PadChar
+	if padChar == 0 {
+		padChar = '_'
+	}
+	return code + strings.Repeat(string(padChar), enc.Width-len(r))
+}
+
+// remap returns code with its symbols replaced per enc.Symbols.
+func (enc *Encoder) remap(code string) string {
+	if len(enc.Symbols) == 0 {
+		return code
+	}
+	var b strings.Builder
+	for _, r := range code {
+		if s, ok := enc.Symbols[r]; ok {
+			b.WriteString(s)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// EncodeStrict is like Encode but returns an error if word contains a
+// character that DoubleMetaphone does not encode and would silently drop.
+// Supported characters are the letters A-Z, Ç and Ñ in either case,
+// spaces, apostrophes and hyphens, digits if enc.Digits is true, and the
+// characters enc.OtherPunct strips or splits at if it is not PunctKeep.
+// The error wraps ErrUnsupportedChar, or is ErrEmptyWord if word is
+// empty.
+func (enc *Encoder) EncodeStrict(word string) (metaph, metaph2 string,
+	err error) {
+	if len(word) == 0 {
+		err = ErrEmptyWord
+		return
+	}
+	if enc == nil {
+		enc = &Encoder{}
+	}
+	if err = checkAlphabet(word, enc.Digits,
+		enc.OtherPunct != PunctKeep); err != nil {
+		return
+	}
+	metaph, metaph2 = enc.Encode(word)
+	return
+}
+
+// checkAlphabet returns an error naming the first character of word that
+// EncodeStrict does not support, or nil.  Digits are supported if digits
+// is true, and other punctuation, symbols and emoji if other is true.
+func checkAlphabet(word string, digits, other bool) error {
+	for i, r := range word {
+		switch {
+		case 'A' <= r && r <= 'Z', 'a' <= r && r <= 'z':
+		case digits && '0' <= r && r <= '9':
+		case other && isOtherPunct(r):
+		case strings.ContainsRune("ÇçÑñ '’-‐", r):
+		default:
+			return fmt.Errorf("%w %q at byte %d of %q",
+				ErrUnsupportedChar, r, i, word)
+		}
+	}
+	return nil
+}
+
+// truncate returns code limited to enc's maximum length.
+func (enc *Encoder) truncate(code string) string {
+	maxLen := enc.MaxLen
+	if maxLen < 1 {
+		maxLen = 4
+	}
+	if len(code) > maxLen {
+		code = code[:maxLen]
+	}
+	return code
+}
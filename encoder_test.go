@@ -0,0 +1,184 @@
+// encoder_test.go - test encoder.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPunctPolicy(t *testing.T) {
+	tests := []struct {
+		policy  PunctPolicy
+		word    string
+		m, m2   string
+		maxLen  int
+		comment string
+	}{
+		{PunctKeep, "mother-in-law", "M0RN", "MTRN", 4, "like DoubleMetaphone"},
+		{PunctStrip, "mother-in-law", "M0RN", "MTRN", 4, ""},
+		{PunctSplit, "mother-in-law", "M0RANL", "MTRANLF", 8, "parts encoded alone"},
+		{PunctSplit, "O'Brien", "APRN", "", 8, ""},
+	}
+	for _, tt := range tests {
+		enc := &Encoder{MaxLen: tt.maxLen, Apostrophes: tt.policy, Hyphens: tt.policy}
+		if m, m2 := enc.Encode(tt.word); m != tt.m || m2 != tt.m2 {
+			t.Errorf("policy %d %q got: %q %q;  want: %q %q",
+				tt.policy, tt.word, m, m2, tt.m, tt.m2)
+		}
+	}
+
+	opts := &Options{Encoder: &Encoder{Apostrophes: PunctStrip}}
+	metaph := NewMetaphMapWithOptions([]string{"O'Brien"}, 4, opts)
+	if got := metaph.MatchWord("OBrien"); len(got) != 1 {
+		t.Errorf("got: %v;  want: [O'Brien]", got)
+	}
+}
+
+func TestSymbols(t *testing.T) {
+	enc := &Encoder{Symbols: map[rune]string{'0': "TH", 'X': "SH"}}
+	m, m2 := enc.Encode("Thatcher")
+	if m != "THSHR" || m2 != "TSHR" {
+		t.Errorf("got: %s, %s;  want: THSHR, TSHR", m, m2)
+	}
+}
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		word  string
+		pad   rune
+		m, m2 string
+	}{
+		{"Lee", 0, "L___", ""},
+		{"Smith", 0, "SMTH", "SHMT"},
+		{"Ng", ' ', "NK  ", ""},
+		{"Thatcher", 0, "THSH", "TSHR"},
+	}
+	for _, tt := range tests {
+		enc := &Encoder{Width: 4, PadChar: tt.pad,
+			Symbols: map[rune]string{'0': "TH", 'X': "SH"}}
+		if m, m2 := enc.Encode(tt.word); m != tt.m || m2 != tt.m2 {
+			t.Errorf("%s got: %q, %q;  want: %q, %q", tt.word, m, m2, tt.m, tt.m2)
+		}
+	}
+}
+
+func TestEncodeStrict(t *testing.T) {
+	enc := NewEncoder(4)
+	if m, _, err := enc.EncodeStrict("O'Ñeill-Çe"); err != nil || m == "" {
+		t.Errorf("got: %q, %v;  want a code and nil", m, err)
+	}
+	if _, _, err := enc.EncodeStrict("Sm1th"); !errors.Is(err, ErrUnsupportedChar) {
+		t.Errorf("Sm1th got: %v;  want ErrUnsupportedChar", err)
+	}
+	if _, _, err := enc.EncodeStrict(""); err != ErrEmptyWord {
+		t.Errorf("empty word got: %v;  want ErrEmptyWord", err)
+	}
+	name := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(name, []byte("Smith\r\nJ0nes\r\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	opts := &Options{Encoder: &Encoder{Strict: true}}
+	_, err := NewMetaphMapFromFileWithOptions(name, 4, opts)
+	if !errors.Is(err, ErrDictionaryFormat) || !errors.Is(err, ErrUnsupportedChar) ||
+		!strings.Contains(err.Error(), "line 2") {
+		t.Errorf("got: %v;  want an error for line 2", err)
+	}
+	if _, err = NewMetaphMapFromFile(name, 4); err != nil {
+		t.Errorf("not strict got: %v", err)
+	}
+}
+
+func TestVowels(t *testing.T) {
+	enc := &Encoder{MaxLen: 8, Vowels: true}
+	tests := []struct {
+		word, m string
+	}{
+		{"Rhiannon", "RANAN"},
+		{"Rene", "RANA"},
+		{"Ron", "RAN"},
+		{"Ruan", "RAN"},
+		{"Anna", "ANA"},
+	}
+	for _, tt := range tests {
+		if m, _ := enc.Encode(tt.word); m != tt.m {
+			t.Errorf("%s got: %s;  want: %s", tt.word, m, tt.m)
+		}
+	}
+}
+
+func TestDropTrailingS(t *testing.T) {
+	enc := &Encoder{MaxLen: 6, DropTrailingS: true}
+	plain := &Encoder{MaxLen: 6}
+	same := [][2]string{
+		{"cats", "cat"}, {"cat's", "cat"}, {"cats'", "cat"},
+		{"cities", "city"}, {"Jones’s", "Jones"}, {"glass", "glass"},
+		{"census", "census"}, {"analysis", "analysis"}, {"bus", "bus"},
+	}
+	for _, p := range same {
+		m, _ := enc.Encode(p[0])
+		n, _ := plain.Encode(p[1])
+		if m != n {
+			t.Errorf("%s got: %s;  want: %s", p[0], m, n)
+		}
+	}
+}
+
+func TestDigits(t *testing.T) {
+	enc := &Encoder{MaxLen: 8, Digits: true}
+	plain := &Encoder{MaxLen: 8, Hyphens: PunctSplit}
+	same := [][2]string{
+		{"K9", "K-nine"},
+		{"B2B", "B-two-B"},
+		{"R2D2", "R-two-D-two"},
+		{"xXsniper2024", "xXsniper-two-thousand-twenty-four"},
+		{"SKU00731", "SKU-zero-zero-seven-three-one"},
+	}
+	for _, p := range same {
+		m, m2 := enc.Encode(p[0])
+		n, n2 := plain.Encode(p[1])
+		if m != n || m2 != n2 {
+			t.Errorf("%s got: %s, %s;  want: %s, %s", p[0], m, m2, n, n2)
+		}
+	}
+	if m, _ := enc.Encode("K9"); m != "KNN" {
+		t.Errorf("K9 got: %s;  want: KNN", m)
+	}
+	if _, _, err := enc.EncodeStrict("B2B"); err != nil {
+		t.Errorf("got: %v;  want: nil", err)
+	}
+	if _, _, err := plain.EncodeStrict("B2B"); err == nil {
+		t.Errorf("got: nil;  want: error")
+	}
+}
+
+func TestOtherPunct(t *testing.T) {
+	tests := []struct {
+		policy      PunctPolicy
+		word, m, m2 string
+		strictErr   bool
+	}{
+		{PunctKeep, "Yahoo!", "AH", "", true},
+		{PunctStrip, "Yahoo!", "AH", "", false},
+		{PunctStrip, "rock👍🏽on", "RKN", "", false},
+		{PunctSplit, "rock👍🏽on", "RKAN", "", false},
+		{PunctSplit, "tom&jerry", "TMJR", "TMAR", false},
+		{PunctKeep, "tom&jerry", "TMJR", "", true},
+	}
+	for _, tt := range tests {
+		enc := &Encoder{MaxLen: 6, OtherPunct: tt.policy}
+		if m, m2 := enc.Encode(tt.word); m != tt.m || m2 != tt.m2 {
+			t.Errorf("%v %s got: %s, %s;  want: %s, %s", tt.policy, tt.word,
+				m, m2, tt.m, tt.m2)
+		}
+		_, _, err := enc.EncodeStrict(tt.word)
+		if (err != nil) != tt.strictErr {
+			t.Errorf("%v %s got: %v;  want error: %v", tt.policy, tt.word,
+				err, tt.strictErr)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// enhanced.go - opt-in enhanced rules for modern English.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import "strconv"
+
+// EnhancedVersion identifies a version of the enhanced rules, which an
+// Encoder applies on top of its AlgorithmVersion when asked to.  The
+// enhanced rules are this package's own, not those of the commercial
+// Metaphone 3.  They are versioned separately from AlgorithmVersion, and
+// like it, a version never changes once released, so codes stored with
+// one version keep their meaning.
+type EnhancedVersion int
+
+const (
+	// EnhancedOff applies no enhanced rules.
+	EnhancedOff EnhancedVersion = iota
+	// Enhanced1 drops the silent H of "honest", "hour" and "heir", the
+	// silent L of "calm", "walk" and "could", the silent B of "lamb" and G
+	// of "phlegm", and the silent first letter of "tsar", "pterodactyl"
+	// and "mnemonic", so each sounds like its spelling without the silent
+	// letter.
+	Enhanced1
+
+	// LatestEnhanced is the newest EnhancedVersion.
+	LatestEnhanced = Enhanced1
+)
+
+// String returns the name of v, such as "Enhanced1".
+func (v EnhancedVersion) String() string {
+	switch v {
+	case EnhancedOff:
+		return "EnhancedOff"
+	case Enhanced1:
+		return "Enhanced1"
+	}
+	return "EnhancedVersion(" + strconv.Itoa(int(v)) + ")"
+}
+
+// enhancedRules holds the enhanced rules and the version that added each.
+var enhancedRules = []struct {
+	since EnhancedVersion
+	rule  Rule
+}{
+	{Enhanced1, Rule{Match: "HONES", At: "start", Primary: "ANS"}},
+	{Enhanced1, Rule{Match: "HONOR", At: "start", Primary: "ANR"}},
+	{Enhanced1, Rule{Match: "HONOUR", At: "start", Primary: "ANR"}},
+	{Enhanced1, Rule{Match: "HOUR", At: "start", Primary: "AR"}},
+	{Enhanced1, Rule{Match: "HEIR", At: "start", Primary: "AR"}},
+	{Enhanced1, Rule{Match: "TS", At: "start", Primary: "S"}},
+	{Enhanced1, Rule{Match: "PT", At: "start", Primary: "T"}},
+	{Enhanced1, Rule{Match: "MN", At: "start", Primary: "N"}},
+	{Enhanced1, Rule{Match: "ALM", At: "end", Primary: "M"}},
+	{Enhanced1, Rule{Match: "ALK", At: "end", Primary: "K"}},
+	{Enhanced1, Rule{Match: "OLK", At: "end", Primary: "K"}},
+	{Enhanced1, Rule{Match: "OULD", At: "end", Primary: "T"}},
+	{Enhanced1, Rule{Match: "MB", At: "end", Primary: "M"}},
+	{Enhanced1, Rule{Match: "GM", At: "end", Primary: "M"}},
+}
+
+// rules returns enc's Rules followed by the enhanced rules of
+// enc.Enhanced, so that enc's own Rules take precedence.
+func (enc *Encoder) rules() (rules Rules) {
+	if enc.Enhanced == EnhancedOff {
+		return enc.Rules
+	}
+	rules = append(rules, enc.Rules...)
+	for _, e := range enhancedRules {
+		if e.since <= enc.Enhanced {
+			r := e.rule
+			r.enhanced = true
+			rules = append(rules, r)
+		}
+	}
+	return
+}
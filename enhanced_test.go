@@ -0,0 +1,59 @@
+// enhanced_test.go - test enhanced.go.
+// This file is public domain.
+
+package metaphone
+
+import "testing"
+
+func TestEnhanced(t *testing.T) {
+	plain := &Encoder{MaxLen: 6}
+	enc := &Encoder{MaxLen: 6, Enhanced: Enhanced1}
+	pairs := [][2]string{
+		{"honest", "onest"},
+		{"hour", "our"},
+		{"heir", "air"},
+		{"tsar", "czar"},
+		{"pterodactyl", "terodactyl"},
+		{"mnemonic", "nemonic"},
+		{"calm", "com"},
+		{"walk", "wok"},
+		{"would", "wood"},
+		{"lamb", "lam"},
+		{"climb", "clime"},
+		{"phlegm", "flem"},
+	}
+	for _, p := range pairs {
+		m, _ := enc.Encode(p[0])
+		n, _ := enc.Encode(p[1])
+		if m != n {
+			t.Errorf("%s got: %s;  want: %s, as for %s", p[0], m, n, p[1])
+		}
+		if m0, _ := plain.Encode(p[0]); m0 == m {
+			t.Errorf("%s: enhanced code %s same as without", p[0], m)
+		}
+	}
+	for _, word := range []string{"honey", "hotel", "Jose", "Smith"} {
+		m, m2 := enc.Encode(word)
+		n, n2 := plain.Encode(word)
+		if m != n || m2 != n2 {
+			t.Errorf("%s got: %s, %s;  want: %s, %s", word, m, m2, n, n2)
+		}
+	}
+	if got := Enhanced1.String(); got != "Enhanced1" {
+		t.Errorf("got: %s;  want: Enhanced1", got)
+	}
+}
+
+func TestEnhancedMultibyte(t *testing.T) {
+	for _, v := range []AlgorithmVersion{Version1, Version2} {
+		enc := &Encoder{MaxLen: 10, Version: v, Enhanced: Enhanced1}
+		for _, word := range []string{"ÉÉÉÉÉÉtalk", "ＳＭＩＴＨ", "ñandú"} {
+			enc.Encode(word)
+		}
+	}
+	metaph := NewMetaphMapWithOptions([]string{"ＳＭＩＴＨ", "Smith"}, 4,
+		&Options{Encoder: &Encoder{Enhanced: Enhanced1}})
+	if got := metaph.MatchWord("Smyth"); len(got) != 1 || got[0] != "Smith" {
+		t.Errorf("Smyth got: %q;  want: [Smith]", got)
+	}
+}
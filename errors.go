@@ -0,0 +1,32 @@
+// errors.go - errors returned by this package.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import "errors"
+
+// Errors returned by this package wrap these, so callers can test for them
+// with errors.Is.
+var (
+	// ErrEmptyWord means a word to encode is empty.
+	ErrEmptyWord = errors.New("metaphone: empty word")
+	// ErrUnsupportedChar means a word has a character that is not
+	// encoded.
+	ErrUnsupportedChar = errors.New("metaphone: unsupported character")
+	// ErrDictionaryFormat means a word list or dictionary is malformed.
+	ErrDictionaryFormat = errors.New("metaphone: bad dictionary format")
+	// ErrIncompatibleMaxLen means two MetaphMaps with different maximum
+	// code lengths, whose codes cannot be compared, were combined.
+	ErrIncompatibleMaxLen = errors.New("metaphone: incompatible maxLen")
+	// ErrCorruptIndex means a stored index is damaged or truncated.
+	ErrCorruptIndex = errors.New("metaphone: corrupt index")
+	// ErrIncompatibleIndex means a stored index is in a format this
+	// package cannot read, or was made with an AlgorithmVersion,
+	// EnhancedVersion or Rhyme setting other than that of the Options it
+	// is queried with.  An index does not record its other settings, such
+	// as Normalizers or Rules, so a mismatch of those is not detected.
+	ErrIncompatibleIndex = errors.New("metaphone: incompatible index")
+	// ErrTrainingData means labeled data to fit a Calibrator to lacks
+	// either matching or non-matching pairs.
+	ErrTrainingData = errors.New("metaphone: insufficient training data")
+)
@@ -0,0 +1,125 @@
+// export.go - export the contents of a MetaphMap.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"iter"
+	"slices"
+	"sort"
+)
+
+// Group is a DoubleMetaphone code and the words that have it.
+type Group struct {
+	Code  string   `json:"code"`
+	Words []string `json:"words"`
+}
+
+// Groups returns the homophone groups of metaph: each code with at least
+// minSize distinct words.  Groups are sorted by code and their words are
+// sorted.
+func (metaph *MetaphMap) Groups(minSize int) (groups []Group) {
+	if metaph == nil {
+		return
+	}
+	for code, words := range metaph.mapper {
+		words = removeDups(words)
+		if len(words) >= minSize {
+			sort.Strings(words)
+			groups = append(groups, Group{Code: code, Words: words})
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Code < groups[j].Code
+	})
+	return
+}
+
+// All returns an iterator over each code of metaph and its words, in code
+// order.  The words of a code are distinct and sorted, and may be changed
+// by the caller.
+func (metaph *MetaphMap) All() iter.Seq2[string, []string] {
+	return func(yield func(string, []string) bool) {
+		if metaph == nil {
+			return
+		}
+		codes := make([]string, 0, len(metaph.mapper))
+		for code := range metaph.mapper {
+			codes = append(codes, code)
+		}
+		slices.Sort(codes)
+		for _, code := range codes {
+			words := removeDups(metaph.mapper[code])
+			slices.Sort(words)
+			if !yield(code, words) {
+				return
+			}
+		}
+	}
+}
+
+// WriteGroupsCSV writes groups to w as CSV, one record per group: the
+// code followed by the group's words.
+func WriteGroupsCSV(w io.Writer, groups []Group) error {
+	cw := csv.NewWriter(w)
+	for _, g := range groups {
+		if err := cw.Write(append([]string{g.Code}, g.Words...)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteGroupsJSON writes groups to w as a JSON array of objects with
+// "code" and "words" members.
+func WriteGroupsJSON(w io.Writer, groups []Group) error {
+	if groups == nil {
+		groups = []Group{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(groups)
+}
+
+// ExportCSV writes the (code, word) pairs of metaph to w as CSV with a
+// "code,word" header, for bulk loading into another system.  Pairs are
+// sorted by code, then word.
+func (metaph *MetaphMap) ExportCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"code", "word"}); err != nil {
+		return err
+	}
+	for _, g := range metaph.Groups(1) {
+		for _, word := range g.Words {
+			if err := cw.Write([]string{g.Code, word}); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ExportJSON writes the (code, word) pairs of metaph to w as newline
+// delimited JSON, one {"code":...,"word":...} object per line, which
+// BigQuery and the Elasticsearch bulk tools load directly.  Pairs are
+// sorted as for ExportCSV.
+func (metaph *MetaphMap) ExportJSON(w io.Writer) error {
+	type pair struct {
+		Code string `json:"code"`
+		Word string `json:"word"`
+	}
+	enc := json.NewEncoder(w)
+	for _, g := range metaph.Groups(1) {
+		for _, word := range g.Words {
+			if err := enc.Encode(pair{g.Code, word}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
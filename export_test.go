@@ -0,0 +1,65 @@
+// export_test.go - test export.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGroups(t *testing.T) {
+	metaph := NewMetaphMap([]string{"Smyth", "Smith", "Jones", "Smith"}, 4)
+	groups := metaph.Groups(2)
+	var b strings.Builder
+	if err := WriteGroupsCSV(&b, groups); err != nil {
+		t.Fatal(err)
+	}
+	want := "SM0,Smith,Smyth\nXMT,Smith,Smyth\n"
+	if b.String() != want {
+		t.Errorf("got: %q;  want: %q", b.String(), want)
+	}
+	b.Reset()
+	if err := WriteGroupsJSON(&b, groups[:1]); err != nil {
+		t.Fatal(err)
+	}
+	want = "[\n  {\n    \"code\": \"SM0\",\n    \"words\": [\n      \"Smith\",\n      \"Smyth\"\n    ]\n  }\n]\n"
+	if b.String() != want {
+		t.Errorf("got: %q;  want: %q", b.String(), want)
+	}
+}
+
+func TestExport(t *testing.T) {
+	metaph := NewMetaphMap([]string{"Smith", "Schmidt", "Smith"}, 4)
+	var b strings.Builder
+	if err := metaph.ExportCSV(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := "code,word\nSM0,Smith\nSMT,Schmidt\nXMT,Schmidt\nXMT,Smith\n"
+	if got := b.String(); got != want {
+		t.Errorf("got: %q;  want: %q", got, want)
+	}
+	b.Reset()
+	if err := metaph.ExportJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+	want = `{"code":"SM0","word":"Smith"}` + "\n" +
+		`{"code":"SMT","word":"Schmidt"}` + "\n" +
+		`{"code":"XMT","word":"Schmidt"}` + "\n" +
+		`{"code":"XMT","word":"Smith"}` + "\n"
+	if got := b.String(); got != want {
+		t.Errorf("got: %q;  want: %q", got, want)
+	}
+}
+
+func TestAll(t *testing.T) {
+	metaph := NewMetaphMap([]string{"Smith", "Schmidt", "Smith", "Jones"}, 4)
+	var got []string
+	for code, words := range metaph.All() {
+		got = append(got, code+":"+strings.Join(words, ","))
+	}
+	want := "ANS:Jones JNS:Jones SM0:Smith SMT:Schmidt XMT:Schmidt,Smith"
+	if strings.Join(got, " ") != want {
+		t.Errorf("got: %v;  want: %s", got, want)
+	}
+}
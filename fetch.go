@@ -0,0 +1,146 @@
+// fetch.go - fetch word lists from web servers and cache them.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// FetchTimeout limits the time FetchWordlist spends fetching a word list
+// with a nil client, so a server that hangs cannot block it, and a Build
+// with FromURL, forever.
+const FetchTimeout = 2 * time.Minute
+
+// fetchClient is the client FetchWordlist uses if it is given none.
+var fetchClient = &http.Client{Timeout: FetchTimeout}
+
+// fetchMeta is what FetchWordlist keeps with a cached word list to
+// revalidate it.
+type fetchMeta struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// FetchWordlist fetches the word list at HTTP or HTTPS URL rawURL into
+// directory cacheDir, which is made if need be, and returns the name of
+// the cached file, to give to NewMetaphMapFromFile or Builder's FromFile.
+// The file keeps the extension of the URL's path, so a ".gz" or ".zip"
+// word list is read as such.
+//
+// A word list already in the cache is revalidated with the ETag and
+// Last-Modified headers it was served with, so a server that answers 304
+// Not Modified sends it only once.  If the server cannot be reached or
+// answers with an error, a cached copy is returned along with no error,
+// so services can start while the server is down or hangs; the error is
+// returned only if there is no cached copy.  client can be nil for a
+// client like http.DefaultClient but with a timeout of FetchTimeout.
+func FetchWordlist(ctx context.Context, client *http.Client, rawURL,
+	cacheDir string) (fileName string, err error) {
+	var u *url.URL
+	if u, err = url.Parse(rawURL); err != nil ||
+		u.Scheme != "http" && u.Scheme != "https" {
+		err = fmt.Errorf("%q is not an HTTP or HTTPS URL", rawURL)
+		return
+	}
+	if client == nil {
+		client = fetchClient
+	}
+	if err = os.MkdirAll(cacheDir, 0755); err != nil {
+		err = fmt.Errorf("trying to make cache directory %s: %v", cacheDir, err)
+		return
+	}
+	sum := sha256.Sum256([]byte(rawURL))
+	base := filepath.Join(cacheDir, hex.EncodeToString(sum[:16]))
+	fileName = base + path.Ext(u.Path)
+	// not base + ".json", which is fileName for a URL of a ".json" file
+	metaName := base + ".meta.json"
+
+	var meta fetchMeta
+	cached := false
+	if b, e := os.ReadFile(metaName); e == nil && json.Unmarshal(b, &meta) == nil {
+		_, e = os.Stat(fileName)
+		cached = e == nil && meta.URL == rawURL
+	}
+	if !cached {
+		meta = fetchMeta{URL: rawURL}
+	}
+	if err = fetch(ctx, client, &meta, fileName, metaName); err != nil &&
+		cached {
+		err = nil
+	}
+	return
+}
+
+// fetch gets meta.URL into file fileName unless it is not modified since
+// meta's ETag or LastModified, and writes meta, updated, to file metaName.
+func fetch(ctx context.Context, client *http.Client, meta *fetchMeta,
+	fileName, metaName string) (err error) {
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(ctx, http.MethodGet, meta.URL,
+		nil); err != nil {
+		err = fmt.Errorf("trying to make request for %s: %v", meta.URL, err)
+		return
+	}
+	if meta.ETag != "" {
+		req.Header.Set("If-None-Match", meta.ETag)
+	}
+	if meta.LastModified != "" {
+		req.Header.Set("If-Modified-Since", meta.LastModified)
+	}
+	var resp *http.Response
+	if resp, err = client.Do(req); err != nil {
+		err = fmt.Errorf("trying to fetch %s: %v", meta.URL, err)
+		return
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return
+	case http.StatusOK:
+	default:
+		err = fmt.Errorf("trying to fetch %s: %s", meta.URL, resp.Status)
+		return
+	}
+
+	// Write to a temporary file and rename it, so a failed or concurrent
+	// fetch never leaves a partial word list in the cache.
+	var fp *os.File
+	dir := filepath.Dir(fileName)
+	if fp, err = os.CreateTemp(dir, "fetch-*"); err != nil {
+		err = fmt.Errorf("trying to create file in %s: %v", dir, err)
+		return
+	}
+	defer os.Remove(fp.Name())
+	_, err = io.Copy(fp, resp.Body)
+	if e := fp.Close(); err == nil {
+		err = e
+	}
+	if err != nil {
+		err = fmt.Errorf("trying to fetch %s: %v", meta.URL, err)
+		return
+	}
+	if err = os.Rename(fp.Name(), fileName); err != nil {
+		err = fmt.Errorf("trying to rename file to %s: %v", fileName, err)
+		return
+	}
+	meta.ETag = resp.Header.Get("ETag")
+	meta.LastModified = resp.Header.Get("Last-Modified")
+	b, _ := json.Marshal(meta)
+	if err = os.WriteFile(metaName, b, 0644); err != nil {
+		err = fmt.Errorf("trying to write file %s: %v", metaName, err)
+	}
+	return
+}
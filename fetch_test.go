@@ -0,0 +1,116 @@
+// fetch_test.go - test fetch.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchWordlist(t *testing.T) {
+	gets, sends := 0, 0
+	up := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter,
+		r *http.Request) {
+		if !up {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		gets++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		sends++
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("Smith\nSmyth\n"))
+	}))
+	defer srv.Close()
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	for range 2 {
+		name, err := FetchWordlist(ctx, nil, srv.URL+"/words.txt", dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b, err := os.ReadFile(name); err != nil || string(b) != "Smith\nSmyth\n" {
+			t.Errorf("got: %q, %v", b, err)
+		}
+	}
+	if gets != 2 || sends != 1 {
+		t.Errorf("got %d requests, %d sent;  want 2, 1", gets, sends)
+	}
+
+	up = false
+	metaph, err := NewBuilder().FromURL(srv.URL+"/words.txt", dir).Build()
+	if err != nil {
+		t.Fatalf("server down with cached copy got: %v", err)
+	}
+	if got := metaph.MatchWord("smith"); len(got) != 2 {
+		t.Errorf("got: %v", got)
+	}
+	if _, err = FetchWordlist(ctx, nil, srv.URL+"/other.txt", dir); err == nil {
+		t.Errorf("server down without cached copy got nil error")
+	}
+	if _, err = FetchWordlist(ctx, nil, "file:///etc/passwd", dir); err == nil {
+		t.Errorf("file URL got nil error")
+	}
+
+	// A word list whose name ends in ".json" is not overwritten by the
+	// metadata cached with it.
+	up = true
+	for range 2 {
+		name, err := FetchWordlist(ctx, nil, srv.URL+"/words.json", dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b, err := os.ReadFile(name); err != nil || string(b) != "Smith\nSmyth\n" {
+			t.Errorf(".json got: %q, %v", b, err)
+		}
+	}
+}
+
+func TestFetchWordlistTimeout(t *testing.T) {
+	hang := make(chan struct{})
+	var hung atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter,
+		r *http.Request) {
+		if hung.Load() {
+			<-hang
+			return
+		}
+		w.Write([]byte("Smith\nSmyth\n"))
+	}))
+	defer srv.Close()
+	defer close(hang)
+	dir := t.TempDir()
+	url := srv.URL + "/words.txt"
+	b := NewBuilder().HTTPClient(&http.Client{Timeout: 100 * time.Millisecond}).
+		FromURL(url, dir)
+	if _, err := b.Build(); err != nil {
+		t.Fatal(err)
+	}
+	hung.Store(true)
+	start := time.Now()
+	metaph, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("Build took %v", d)
+	}
+	if got := len(metaph.MatchWord("Smith")); got != 2 {
+		t.Errorf("got: %d;  want: 2 cached words", got)
+	}
+	if fetchClient.Timeout != FetchTimeout {
+		t.Errorf("default client timeout got: %v;  want: %v",
+			fetchClient.Timeout, FetchTimeout)
+	}
+}
@@ -0,0 +1,97 @@
+// fuzz_test.go - fuzz the encoders for panics and malformed codes.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+// fuzzSeeds are awkward inputs for the fuzz tests.
+var fuzzSeeds = []string{"", " ", "X", "GN", "Ç", "Ñ", "CH", "\xff\xfe",
+	"Dübois", "mother-in-law", "O'Brien", "     ", "WITZ", "SCHMIDT",
+	"ñññ", "ÇÇÇÇÇÇÇÇ", "\x00", "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+	"ÉÉÉÉÉÉtalk", "ＡＢＣ", "ＳＭＩＴＨ", "Größenwahn", "Muñoz"}
+
+func FuzzDoubleMetaphone(f *testing.F) {
+	for _, s := range fuzzSeeds {
+		f.Add(s, 4)
+	}
+	f.Fuzz(func(t *testing.T, word string, maxLen int) {
+		maxLen %= 64
+		m, m2 := DoubleMetaphone(word, maxLen)
+		if maxLen < 1 {
+			maxLen = 4
+		}
+		if len(m) > maxLen || len(m2) > maxLen {
+			t.Errorf("%q, %d got: %q, %q", word, maxLen, m, m2)
+		}
+		for _, code := range []string{m, m2} {
+			for _, r := range code {
+				if !('A' <= r && r <= 'Z' || r == '0') {
+					t.Errorf("%q got code %q", word, code)
+				}
+			}
+		}
+	})
+}
+
+func FuzzEncoder(f *testing.F) {
+	for _, s := range fuzzSeeds {
+		f.Add(s)
+	}
+	rules := Rules{{Match: "PH", At: "start", Primary: "P"},
+		{Match: "GH", At: "end", Primary: ""},
+		{Match: "Ñ", Primary: "NY", Secondary: "N"}}
+	encoders := []*Encoder{nil, {}, {MaxLen: 8, Version: Version2,
+		Apostrophes: PunctSplit, Hyphens: PunctStrip, AutoLanguage: true,
+		Symbols: map[rune]string{'0': "θ"}, Width: 6},
+		{MaxLen: 10, Enhanced: Enhanced1},
+		{MaxLen: 10, Version: Version2, Enhanced: Enhanced1, Rules: rules},
+		{MaxLen: 6, Rules: rules},
+		{MaxLen: 6, AutoLanguage: true, Acronyms: true, Digits: true},
+		{MaxLen: 6, Version: Version3, AutoLanguage: true, Rules: rules}}
+	f.Fuzz(func(t *testing.T, word string) {
+		for _, enc := range encoders {
+			m, m2 := enc.Encode(word)
+			enc.EncodePhrase(word)
+			if !utf8.ValidString(m) || !utf8.ValidString(m2) {
+				t.Errorf("%q got invalid codes %q, %q", word, m, m2)
+			}
+			for _, lang := range []string{"de", "es-MX", "en", "xx"} {
+				m, m2 = enc.EncodeLang(word, lang)
+				if !utf8.ValidString(m) || !utf8.ValidString(m2) {
+					t.Errorf("%q %s got invalid codes %q, %q", word, lang,
+						m, m2)
+				}
+			}
+		}
+		Koelner(word)
+		SpanishMetaphone(word, 0)
+		SpellNumerals(word)
+		PorterStem(word)
+		StripHonorifics(word)
+		StripPlural(word)
+	})
+}
+
+func TestNilReceivers(t *testing.T) {
+	var metaph *MetaphMap
+	if metaph.Len() != 0 || metaph.MatchWord("Smith") != nil ||
+		metaph.ContainsSoundAlike("Smith") || metaph.Stats() != (MapStats{}) ||
+		metaph.Add("Smith", nil) || metaph.RemoveWord("Smith") ||
+		metaph.Words() != nil || metaph.Collisions(3) != nil {
+		t.Errorf("nil MetaphMap got results")
+	}
+	if _, err := metaph.Union(NewMetaphMap(nil, 4)); err == nil {
+		t.Errorf("Union of nil MetaphMap got nil error")
+	}
+	var enc *Encoder
+	if m, _ := enc.Encode("Smith"); m != "SM0" {
+		t.Errorf("nil Encoder got: %s;  want: SM0", m)
+	}
+	if _, err := ScanDocument(nil, nil); err == nil {
+		t.Errorf("ScanDocument with nil dictionary got nil error")
+	}
+}
@@ -0,0 +1,5 @@
+module github.com/charltoncr/metaphone
+
+go 1.25.0
+
+require golang.org/x/text v0.37.0
@@ -0,0 +1,57 @@
+// hooks.go - observe encoding and matching.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+// Hooks holds optional functions that are called as an Encoder, or a
+// MetaphMap made with it, does its work, so that a service can count,
+// time or log that work without wrapping each function it calls.  A nil
+// function is not called.  The functions must be safe for concurrent use
+// if the Encoder or MetaphMap is used concurrently.
+type Hooks struct {
+	// OnEncode is called with each word encoded and its codes.
+	OnEncode func(word, metaph, metaph2 string)
+	// OnRule is called for each rule DoubleMetaphone applies while an
+	// Encoder encodes a word, with the rune index in the word at which
+	// the rule applied, the rule's name and the codes it added.  A
+	// built-in rule is named by the letters it consumed, such as "CH" or
+	// "A"; others are named "initial GN", "initial X" and "override "
+	// followed by the letters of a Rule.  Counting rules over a corpus
+	// shows which cause the most collisions.
+	OnRule func(pos int, rule string, primary, secondary string)
+	// OnMatch is called with each word looked up by MatchWord and the
+	// words that matched it.
+	OnMatch func(word string, matches []string)
+	// OnCacheHit is called with the key of each lookup answered from a
+	// cache, such as the page cache of an index read from storage.
+	OnCacheHit func(key string)
+	// OnCacheMiss is called with the key of each lookup that a cache
+	// could not answer.
+	OnCacheMiss func(key string)
+}
+
+// encode calls h.OnEncode if h and it are not nil.
+func (h *Hooks) encode(word, metaph, metaph2 string) {
+	if h != nil && h.OnEncode != nil {
+		h.OnEncode(word, metaph, metaph2)
+	}
+}
+
+// match calls h.OnMatch if h and it are not nil.
+func (h *Hooks) match(word string, matches []string) {
+	if h != nil && h.OnMatch != nil {
+		h.OnMatch(word, matches)
+	}
+}
+
+// cache calls h.OnCacheHit or h.OnCacheMiss, per hit, if h and it are not
+// nil.
+func (h *Hooks) cache(key string, hit bool) {
+	switch {
+	case h == nil:
+	case hit && h.OnCacheHit != nil:
+		h.OnCacheHit(key)
+	case !hit && h.OnCacheMiss != nil:
+		h.OnCacheMiss(key)
+	}
+}
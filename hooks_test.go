@@ -0,0 +1,54 @@
+// hooks_test.go - test hooks.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestHooks(t *testing.T) {
+	var encoded, matched []string
+	hooks := &Hooks{
+		OnEncode: func(word, m, m2 string) {
+			encoded = append(encoded, word+"="+m+"/"+m2)
+		},
+		OnMatch: func(word string, matches []string) {
+			matched = append(matched, fmt.Sprint(word, matches))
+		},
+	}
+	metaph := NewMetaphMapWithOptions([]string{"Smith"}, 4,
+		&Options{Encoder: &Encoder{Hooks: hooks}})
+	metaph.MatchWord("Smyth")
+	want := "[Smith=SM0/XMT Smyth=SM0/XMT]"
+	if got := fmt.Sprint(encoded); got != want {
+		t.Errorf("got: %s;  want: %s", got, want)
+	}
+	want = "[Smyth[Smith]]"
+	if got := fmt.Sprint(matched); got != want {
+		t.Errorf("got: %s;  want: %s", got, want)
+	}
+	var h *Hooks
+	h.encode("a", "A", "")
+	h.cache("key", true)
+}
+
+func TestOnRule(t *testing.T) {
+	var fired []string
+	hooks := &Hooks{OnRule: func(pos int, rule, m, m2 string) {
+		fired = append(fired, fmt.Sprintf("%d:%s=%s/%s", pos, rule, m, m2))
+	}}
+	enc := &Encoder{MaxLen: 6, Hooks: hooks}
+	enc.Encode("Knight")
+	want := "[0:initial KN=/ 1:N=N/N 2:I=/ 3:GH=/ 5:T=T/T]"
+	if got := fmt.Sprint(fired); got != want {
+		t.Errorf("got: %s;  want: %s", got, want)
+	}
+	fired = nil
+	enc.Encode("Michael")
+	want = "[0:M=M/M 1:I=/ 2:CH=K/X 4:A=/ 5:E=/ 6:L=L/L]"
+	if got := fmt.Sprint(fired); got != want {
+		t.Errorf("got: %s;  want: %s", got, want)
+	}
+}
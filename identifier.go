@@ -0,0 +1,60 @@
+// identifier.go - split source code identifiers into words.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SplitIdentifier returns the words of a source code identifier written
+// in camelCase, PascalCase, snake_case, kebab-case or a mix of them, such
+// as ["get", "HTTP", "Response", "2"] for "getHTTPResponse_2".  Words
+// break at underscores, hyphens, dots and other characters that are not
+// letters or digits, before a capital that follows a lower-case letter or
+// digit, before the last capital of a run of capitals followed by a
+// lower-case letter, and between letters and digits.
+func SplitIdentifier(id string) (words []string) {
+	runes := []rune(id)
+	start := -1
+	flush := func(end int) {
+		if start >= 0 {
+			words = append(words, string(runes[start:end]))
+		}
+		start = -1
+	}
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush(i)
+			continue
+		}
+		if start >= 0 {
+			prev := runes[i-1]
+			next := rune(0)
+			if i+1 < len(runes) {
+				next = runes[i+1]
+			}
+			switch {
+			case unicode.IsDigit(r) != unicode.IsDigit(prev),
+				unicode.IsUpper(r) && !unicode.IsUpper(prev),
+				unicode.IsUpper(r) && unicode.IsUpper(prev) &&
+					unicode.IsLower(next):
+				flush(i)
+			}
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	flush(len(runes))
+	return
+}
+
+// NormalizeIdentifier is a Normalizer that replaces an identifier with its
+// words, as split by SplitIdentifier, separated by spaces, so that
+// sound-alike search over source code symbols finds "getColor" for
+// "getColour" and "init_connection" for "init_conection".
+func NormalizeIdentifier(id string) string {
+	return strings.Join(SplitIdentifier(id), " ")
+}
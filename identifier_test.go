@@ -0,0 +1,37 @@
+// identifier_test.go - test identifier.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSplitIdentifier(t *testing.T) {
+	tests := []struct{ in, out string }{
+		{"getColour", "[get Colour]"},
+		{"init_conection", "[init conection]"},
+		{"getHTTPResponse_2", "[get HTTP Response 2]"},
+		{"XMLHttpRequest", "[XML Http Request]"},
+		{"utf8Decode", "[utf 8 Decode]"},
+		{"kebab-case.name", "[kebab case name]"},
+		{"__init__", "[init]"},
+		{"", "[]"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprint(SplitIdentifier(tt.in)); got != tt.out {
+			t.Errorf("%q got: %s;  want: %s", tt.in, got, tt.out)
+		}
+	}
+	metaph, _ := NewBuilder().Normalizer(NormalizeIdentifier).MaxLen(8).
+		Words("getColor", "init_connection", "setColor").Build()
+	for query, want := range map[string]string{
+		"getColour":      "[getColor]",
+		"init_conection": "[init_connection]",
+	} {
+		if got := fmt.Sprint(metaph.MatchWord(query)); got != want {
+			t.Errorf("%s got: %s;  want: %s", query, got, want)
+		}
+	}
+}
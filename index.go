@@ -0,0 +1,645 @@
+// index.go - store a MetaphMap as an index on disk and look words up in it.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// An index file is, with all integers little-endian uint32s:
+//
+//	header:  indexMagic, format version, maxLen, AlgorithmVersion,
+//	         EnhancedVersion, flags (indexRhyme), number of codes,
+//	         length of codes, length of words, CRC-32C checksum
+//	table:   for each code, in code order: code offset, code length,
+//	         words offset, words length
+//	codes:   the codes, one after another
+//	words:   for each code, its words, each a uvarint length and its bytes
+//
+// Offsets are from the start of the codes or words section.  The checksum
+// is of the rest of the header and the sections.  Format 2 had no
+// checksum.  Format 1, of indexes written before the format had a
+// version, had only the maxLen and the lengths in its header.
+const (
+	indexMagic     = "MPHI"
+	indexFormat    = 3
+	indexHeaderLen = 40
+	indexEntryLen  = 16
+	// indexRhyme flags the index of a MetaphMap that finds rhymes.
+	indexRhyme = 1
+	// indexV1HeaderLen and indexV2HeaderLen are the lengths of the
+	// headers of formats 1 and 2.
+	indexV1HeaderLen = 16
+	indexV2HeaderLen = 36
+	// indexPageSize is the size of a page of an Index's page cache.
+	indexPageSize = 4096
+	// indexPages is the number of pages an Index caches.
+	indexPages = 64
+)
+
+// indexCRC is the table of the checksum of an index.
+var indexCRC = crc32.MakeTable(crc32.Castagnoli)
+
+// WriteIndex writes metaph to w as an index that ReadIndex and OpenIndex
+// can look words up in without loading it into memory.
+func (metaph *MetaphMap) WriteIndex(w io.Writer) (err error) {
+	if metaph == nil {
+		err = fmt.Errorf("trying to write index: nil MetaphMap")
+		return
+	}
+	var codes []byte
+	var words []byte
+	var table []byte
+	n := 0
+	for code, bucket := range metaph.All() {
+		table = binary.LittleEndian.AppendUint32(table, uint32(len(codes)))
+		table = binary.LittleEndian.AppendUint32(table, uint32(len(code)))
+		codes = append(codes, code...)
+		start := len(words)
+		for _, word := range bucket {
+			words = binary.AppendUvarint(words, uint64(len(word)))
+			words = append(words, word...)
+		}
+		table = binary.LittleEndian.AppendUint32(table, uint32(start))
+		table = binary.LittleEndian.AppendUint32(table, uint32(len(words)-start))
+		n++
+	}
+	h := metaph.indexHeader()
+	h.n, h.codesLen, h.wordsLen = n, len(codes), len(words)
+	h.crc = h.sum(table, codes, words)
+	bw := bufio.NewWriter(w)
+	for _, b := range [][]byte{h.append(nil), table, codes, words} {
+		if _, err = bw.Write(b); err != nil {
+			err = fmt.Errorf("trying to write index: %v", err)
+			return
+		}
+	}
+	if err = bw.Flush(); err != nil {
+		err = fmt.Errorf("trying to write index: %v", err)
+	}
+	return
+}
+
+// indexHeader is the header of an index.
+type indexHeader struct {
+	format, maxLen        int
+	version               AlgorithmVersion
+	enhanced              EnhancedVersion
+	flags                 int
+	n, codesLen, wordsLen int
+	crc                   uint32
+}
+
+// indexHeader returns the header of an index of metaph, without its
+// lengths and checksum.
+func (metaph *MetaphMap) indexHeader() (h indexHeader) {
+	h.format = indexFormat
+	h.maxLen = metaph.maxlen
+	h.version = max(metaph.enc.Version, Version1)
+	h.enhanced = metaph.enc.Enhanced
+	if metaph.opts.Rhyme {
+		h.flags |= indexRhyme
+	}
+	return
+}
+
+// append appends h to b in the current format.
+func (h indexHeader) append(b []byte) []byte {
+	b = append(b, indexMagic...)
+	for _, v := range []int{h.format, h.maxLen, int(h.version),
+		int(h.enhanced), h.flags, h.n, h.codesLen, h.wordsLen} {
+		b = binary.LittleEndian.AppendUint32(b, uint32(v))
+	}
+	return binary.LittleEndian.AppendUint32(b, h.crc)
+}
+
+// size returns the size of the index h is the header of.
+func (h indexHeader) size() int64 {
+	return int64(indexHeaderLen + h.n*indexEntryLen + h.codesLen + h.wordsLen)
+}
+
+// sum returns the checksum of the index with header h and sections.
+func (h indexHeader) sum(sections ...[]byte) uint32 {
+	crc := crc32.Checksum(h.append(nil)[:indexHeaderLen-4], indexCRC)
+	for _, b := range sections {
+		crc = crc32.Update(crc, indexCRC, b)
+	}
+	return crc
+}
+
+// verify returns an error wrapping ErrCorruptIndex if the checksum of the
+// index with header h, read from r, is not h.crc.
+func (h indexHeader) verify(r io.ReaderAt) error {
+	hash := crc32.New(indexCRC)
+	hash.Write(h.append(nil)[:indexHeaderLen-4])
+	sections := io.NewSectionReader(r, indexHeaderLen, h.size()-indexHeaderLen)
+	if _, err := io.Copy(hash, sections); err != nil {
+		return fmt.Errorf("%w: trying to read index: %v", ErrCorruptIndex, err)
+	}
+	if sum := hash.Sum32(); sum != h.crc {
+		return fmt.Errorf("%w: checksum is %08x, not %08x", ErrCorruptIndex,
+			sum, h.crc)
+	}
+	return nil
+}
+
+// parseIndexHeader returns the header at the start of b, of any format,
+// and its length.  b holds at least indexHeaderLen bytes or all of an
+// index.  The settings of a format 1 index are taken from opts, which can
+// be nil.  A format this package does not know is an error that wraps
+// ErrIncompatibleIndex; a b too short for its header is an error that
+// wraps ErrCorruptIndex.
+func parseIndexHeader(b []byte, opts *Options) (h indexHeader, n int,
+	err error) {
+	field := func(i int) int {
+		return int(binary.LittleEndian.Uint32(b[4*i:]))
+	}
+	format := 1
+	if bytes.HasPrefix(b, []byte(indexMagic)) && len(b) >= 8 {
+		format = field(1)
+	}
+	switch format {
+	case 1:
+		n = indexV1HeaderLen
+	case 2:
+		n = indexV2HeaderLen
+	case indexFormat:
+		n = indexHeaderLen
+	default:
+		err = fmt.Errorf("%w: format %d, not %d", ErrIncompatibleIndex,
+			format, indexFormat)
+		return
+	}
+	if len(b) < n {
+		err = fmt.Errorf("%w: trying to read header: %v", ErrCorruptIndex,
+			io.ErrUnexpectedEOF)
+		return
+	}
+	if format == 1 {
+		h = newMetaphMap(field(0), opts).indexHeader()
+		h.n, h.codesLen, h.wordsLen = field(1), field(2), field(3)
+	} else {
+		h = indexHeader{maxLen: field(2), version: AlgorithmVersion(field(3)),
+			enhanced: EnhancedVersion(field(4)), flags: field(5), n: field(6),
+			codesLen: field(7), wordsLen: field(8)}
+	}
+	h.format = format
+	if format == indexFormat {
+		h.crc = uint32(field(9))
+	}
+	return
+}
+
+// readIndexHeader reads the header of an index from r.  An index of an
+// older format is an error that wraps ErrIncompatibleIndex and tells to
+// migrate it.
+func readIndexHeader(r io.ReaderAt) (h indexHeader, err error) {
+	b := make([]byte, indexHeaderLen)
+	n, _ := r.ReadAt(b, 0)
+	if h, _, err = parseIndexHeader(b[:n], nil); err != nil {
+		return
+	}
+	if h.format != indexFormat {
+		err = fmt.Errorf("%w: format %d, which MigrateIndex converts to %d",
+			ErrIncompatibleIndex, h.format, indexFormat)
+	}
+	return
+}
+
+// check returns an error wrapping ErrIncompatibleIndex if metaph has an
+// AlgorithmVersion, EnhancedVersion or Rhyme setting other than that of
+// the index with header h.  These are all the settings that h records;
+// queries use its maxLen, and its other settings, such as Normalizers,
+// must be given alike by the Options of metaph.
+func (h indexHeader) check(metaph *MetaphMap) error {
+	want := metaph.indexHeader()
+	switch {
+	case h.version != want.version:
+		return fmt.Errorf("%w: made with %v, queried with %v",
+			ErrIncompatibleIndex, h.version, want.version)
+	case h.enhanced != want.enhanced:
+		return fmt.Errorf("%w: made with %v, queried with %v",
+			ErrIncompatibleIndex, h.enhanced, want.enhanced)
+	case h.flags != want.flags:
+		return fmt.Errorf("%w: rhyme is %v, queried with %v",
+			ErrIncompatibleIndex, h.flags&indexRhyme != 0, metaph.opts.Rhyme)
+	}
+	return nil
+}
+
+// MigrateIndex reads an index of an older format from r and writes it to
+// w in the current format.  An index of format 1 does not record the
+// settings it was made with, so they are taken from opts, which can be
+// nil and must be the Options the index was made with.  An index already
+// in the current format is checked and copied unchanged.
+func MigrateIndex(r io.Reader, w io.Writer, opts *Options) (err error) {
+	var b []byte
+	if b, err = io.ReadAll(r); err != nil {
+		err = fmt.Errorf("trying to read index: %v", err)
+		return
+	}
+	var h indexHeader
+	var n int
+	if h, n, err = parseIndexHeader(b, opts); err != nil {
+		return
+	}
+	if want := h.size() - indexHeaderLen + int64(n); int64(len(b)) != want {
+		err = fmt.Errorf("%w: size is %d bytes, not %d", ErrCorruptIndex,
+			len(b), want)
+		return
+	}
+	if h.format == indexFormat {
+		if err = h.verify(bytes.NewReader(b)); err != nil {
+			return
+		}
+	} else {
+		h.format = indexFormat
+		h.crc = h.sum(b[n:])
+		b = append(h.append(nil), b[n:]...)
+	}
+	if _, err = w.Write(b); err != nil {
+		err = fmt.Errorf("trying to write index: %v", err)
+	}
+	return
+}
+
+// WriteIndexFile writes metaph to file fileName as WriteIndex does.
+func (metaph *MetaphMap) WriteIndexFile(fileName string) (err error) {
+	var fp *os.File
+	if fp, err = os.Create(fileName); err != nil {
+		err = fmt.Errorf("trying to create file %s: %v", fileName, err)
+		return
+	}
+	if err = metaph.WriteIndex(fp); err != nil {
+		fp.Close()
+		err = fmt.Errorf("file %s: %v", fileName, err)
+		return
+	}
+	if err = fp.Close(); err != nil {
+		err = fmt.Errorf("trying to close file %s: %v", fileName, err)
+	}
+	return
+}
+
+// Index looks words up in an index written by WriteIndex, reading from
+// storage only the parts of it that a lookup needs: MatchWord finds a code
+// by binary search of the index's sorted code table.  Recently read pages
+// are kept in a small cache; Hooks.OnCacheHit and OnCacheMiss of the
+// Encoder of the Options given to ReadIndex or OpenIndex are called with
+// the number of each page looked for.  LoadCodeTable instead keeps the
+// code table in memory and reads the words of each code once.  An Index is
+// safe for concurrent use.
+type Index struct {
+	r       io.ReaderAt
+	closer  io.Closer
+	header  indexHeader
+	size    int64
+	n       int
+	table   int64 // offset of the code table
+	codes   int64 // offset of the codes
+	words   int64 // offset of the words
+	metaph  *MetaphMap
+	mu      sync.Mutex
+	pages   map[int64]*list.Element
+	recent  *list.List // of *indexPage, most recently used first
+	scratch []byte
+	// the code table, if loaded by LoadCodeTable, and the words of each
+	// code looked up since.
+	entries []indexEntry
+	buckets map[string][]string
+}
+
+// indexEntry is an entry of the code table of an Index.
+type indexEntry struct {
+	code     string
+	wordsOff int64
+	wordsLen int
+}
+
+// indexPage is a page of an Index's page cache.
+type indexPage struct {
+	num  int64
+	data []byte
+}
+
+// ReadIndex returns an Index that reads the index of size bytes written
+// by WriteIndex from r.  Queries are encoded with opts, which can be nil
+// and should be the Options the indexed MetaphMap was made with; queries
+// use the index's maxLen.  An index that is too short for its header or
+// whose sections do not add up to size is an error that wraps
+// ErrCorruptIndex.  An index of an older format, or one made with a
+// different AlgorithmVersion, EnhancedVersion or Rhyme setting than opts
+// gives, is an error that wraps ErrIncompatibleIndex.  ReadIndex reads
+// the whole index once to verify its checksum; one that fails is an error
+// that wraps ErrCorruptIndex.
+func ReadIndex(r io.ReaderAt, size int64, opts *Options) (ix *Index, err error) {
+	var h indexHeader
+	if h, err = readIndexHeader(r); err != nil {
+		return
+	}
+	if want := h.size(); want != size {
+		err = fmt.Errorf("%w: size is %d bytes, not %d", ErrCorruptIndex,
+			size, want)
+		return
+	}
+	metaph := newMetaphMap(h.maxLen, opts)
+	if err = h.check(metaph); err != nil {
+		return
+	}
+	if err = h.verify(r); err != nil {
+		return
+	}
+	ix = &Index{
+		r:      r,
+		header: h,
+		size:   size,
+		n:      h.n,
+		table:  indexHeaderLen,
+		codes:  int64(indexHeaderLen + h.n*indexEntryLen),
+		words:  int64(indexHeaderLen + h.n*indexEntryLen + h.codesLen),
+		metaph: metaph,
+		pages:  make(map[int64]*list.Element),
+		recent: list.New(),
+	}
+	return
+}
+
+// OpenIndex opens index file fileName, written by WriteIndex or
+// WriteIndexFile, as ReadIndex does.  Close the Index when done with it.
+func OpenIndex(fileName string, opts *Options) (ix *Index, err error) {
+	var fp *os.File
+	var fi os.FileInfo
+	if fp, err = os.Open(fileName); err != nil {
+		err = fmt.Errorf("trying to open file %s: %v", fileName, err)
+		return
+	}
+	if fi, err = fp.Stat(); err != nil {
+		fp.Close()
+		err = fmt.Errorf("trying to stat file %s: %v", fileName, err)
+		return
+	}
+	if ix, err = ReadIndex(fp, fi.Size(), opts); err != nil {
+		fp.Close()
+		err = fmt.Errorf("file %s: %w", fileName, err)
+		return
+	}
+	ix.closer = fp
+	return
+}
+
+// Close closes the file of an Index opened by OpenIndex.  It does nothing
+// for an Index made by ReadIndex.
+func (ix *Index) Close() error {
+	if ix.closer == nil {
+		return nil
+	}
+	return ix.closer.Close()
+}
+
+// Verify reads all of ix again and returns an error that wraps
+// ErrCorruptIndex if it cannot be read or its checksum is wrong.
+// ReadIndex and OpenIndex verify an index when they load it; Verify
+// re-checks one already open, such as a long-open file that may have been
+// damaged or replaced since.
+func (ix *Index) Verify() error {
+	return ix.header.verify(ix.r)
+}
+
+// Len returns the number of sound-alike entries in ix.
+func (ix *Index) Len() int {
+	if ix == nil {
+		return 0
+	}
+	return ix.n
+}
+
+// MatchWord returns all words in ix that sound like word, as
+// (*MetaphMap).MatchWord does.  An index that cannot be read or is
+// damaged is an error that wraps ErrCorruptIndex.
+func (ix *Index) MatchWord(word string) (output []string, err error) {
+	if ix == nil {
+		return
+	}
+	m, m2 := ix.metaph.encode(word)
+	for _, code := range []string{m, m2} {
+		if len(code) == 0 {
+			continue
+		}
+		var words []string
+		if words, err = ix.lookup(code); err != nil {
+			return nil, err
+		}
+		output = append(output, words...)
+	}
+	output = removeDups(output)
+	ix.metaph.enc.Hooks.match(word, output)
+	return
+}
+
+// LoadCodeTable reads the code table of ix into memory, so that lookups
+// read only the words of a code, once: the words of each code are read
+// from storage the first time it is looked up and are kept for later
+// lookups, with Hooks.OnCacheHit and OnCacheMiss called with the code.
+// An Index with a loaded code table starts quickly and holds in memory
+// only the words of the codes actually queried.  A code table that cannot
+// be read or is damaged is an error that wraps ErrCorruptIndex.
+func (ix *Index) LoadCodeTable() (err error) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	if ix.entries != nil {
+		return
+	}
+	table := make([]byte, ix.codes-ix.table)
+	codes := make([]byte, ix.words-ix.codes)
+	if err = readAt(ix.r, table, ix.table); err == nil {
+		err = readAt(ix.r, codes, ix.codes)
+	}
+	if err != nil {
+		err = fmt.Errorf("%w: trying to read code table: %v",
+			ErrCorruptIndex, err)
+		return
+	}
+	text := string(codes)
+	entries := make([]indexEntry, ix.n)
+	for i := range entries {
+		entry := table[i*indexEntryLen:]
+		codeOff := int(binary.LittleEndian.Uint32(entry))
+		codeLen := int(binary.LittleEndian.Uint32(entry[4:]))
+		if codeOff+codeLen > len(text) {
+			err = fmt.Errorf("%w: code %d is past the end", ErrCorruptIndex, i)
+			return
+		}
+		entries[i] = indexEntry{
+			code:     text[codeOff : codeOff+codeLen],
+			wordsOff: int64(binary.LittleEndian.Uint32(entry[8:])),
+			wordsLen: int(binary.LittleEndian.Uint32(entry[12:])),
+		}
+	}
+	ix.entries = entries
+	ix.buckets = make(map[string][]string)
+	return
+}
+
+// lookup returns the words of code, or nil if ix does not have code.
+func (ix *Index) lookup(code string) (words []string, err error) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	if ix.entries != nil {
+		return ix.lookupLoaded(code)
+	}
+	lo, hi := 0, ix.n
+	for lo < hi {
+		i := lo + (hi-lo)/2
+		var entry []byte
+		if entry, err = ix.read(ix.table+int64(i)*indexEntryLen,
+			indexEntryLen); err != nil {
+			return
+		}
+		codeOff := int64(binary.LittleEndian.Uint32(entry))
+		codeLen := int(binary.LittleEndian.Uint32(entry[4:]))
+		wordsOff := int64(binary.LittleEndian.Uint32(entry[8:]))
+		wordsLen := int(binary.LittleEndian.Uint32(entry[12:]))
+		var b []byte
+		if b, err = ix.read(ix.codes+codeOff, codeLen); err != nil {
+			return
+		}
+		switch c := string(b); {
+		case c < code:
+			lo = i + 1
+		case c > code:
+			hi = i
+		default:
+			if b, err = ix.read(ix.words+wordsOff, wordsLen); err != nil {
+				return
+			}
+			return decodeBucket(b)
+		}
+	}
+	return
+}
+
+// lookupLoaded is lookup for an Index with a loaded code table.
+func (ix *Index) lookupLoaded(code string) (words []string, err error) {
+	hooks := ix.metaph.enc.Hooks
+	if words, ok := ix.buckets[code]; ok {
+		hooks.cache(code, true)
+		return words, nil
+	}
+	hooks.cache(code, false)
+	i, ok := slices.BinarySearchFunc(ix.entries, code,
+		func(e indexEntry, code string) int {
+			return strings.Compare(e.code, code)
+		})
+	if !ok {
+		return
+	}
+	e := ix.entries[i]
+	if e.wordsOff+int64(e.wordsLen) > ix.size-ix.words {
+		err = fmt.Errorf("%w: words of code %s are past the end",
+			ErrCorruptIndex, code)
+		return
+	}
+	b := make([]byte, e.wordsLen)
+	if err = readAt(ix.r, b, ix.words+e.wordsOff); err != nil {
+		err = fmt.Errorf("%w: trying to read words of code %s: %v",
+			ErrCorruptIndex, code, err)
+		return
+	}
+	if words, err = decodeBucket(b); err != nil {
+		return
+	}
+	ix.buckets[code] = words
+	return
+}
+
+// readAt reads len(b) bytes from r at offset off into b.  A ReaderAt may
+// return io.EOF with a read that ends at the end of its input, so that is
+// not an error if all of b was read.
+func readAt(r io.ReaderAt, b []byte, off int64) error {
+	n, err := r.ReadAt(b, off)
+	if err == io.EOF && n == len(b) {
+		err = nil
+	}
+	return err
+}
+
+// decodeBucket returns the words of b, the words of a code in an index.
+func decodeBucket(b []byte) (words []string, err error) {
+	for len(b) > 0 {
+		n, w := binary.Uvarint(b)
+		if w <= 0 || n > uint64(len(b)-w) {
+			err = fmt.Errorf("%w: bad word length", ErrCorruptIndex)
+			return
+		}
+		words = append(words, string(b[w:w+int(n)]))
+		b = b[w+int(n):]
+	}
+	return
+}
+
+// read returns the n bytes of ix at offset off through its page cache.
+// The bytes are valid until the next call of read.
+func (ix *Index) read(off int64, n int) (b []byte, err error) {
+	if off < 0 || off+int64(n) > ix.size {
+		err = fmt.Errorf("%w: offset %d is past the end", ErrCorruptIndex,
+			off+int64(n))
+		return
+	}
+	b = ix.scratch[:0]
+	for n > 0 {
+		var page []byte
+		if page, err = ix.page(off / indexPageSize); err != nil {
+			return
+		}
+		chunk := page[off%indexPageSize:]
+		chunk = chunk[:min(n, len(chunk))]
+		b = append(b, chunk...)
+		off += int64(len(chunk))
+		n -= len(chunk)
+	}
+	ix.scratch = b
+	return
+}
+
+// page returns page num of ix from its page cache, reading it if it is not
+// cached and evicting the least recently used page if the cache is full.
+func (ix *Index) page(num int64) (data []byte, err error) {
+	hooks := ix.metaph.enc.Hooks
+	if e, ok := ix.pages[num]; ok {
+		hooks.cache(strconv.FormatInt(num, 10), true)
+		ix.recent.MoveToFront(e)
+		return e.Value.(*indexPage).data, nil
+	}
+	hooks.cache(strconv.FormatInt(num, 10), false)
+	var p *indexPage
+	if ix.recent.Len() >= indexPages {
+		e := ix.recent.Back()
+		p = ix.recent.Remove(e).(*indexPage)
+		delete(ix.pages, p.num)
+	} else {
+		p = &indexPage{data: make([]byte, indexPageSize)}
+	}
+	off := num * indexPageSize
+	data = p.data[:min(indexPageSize, ix.size-off)]
+	if err = readAt(ix.r, data, off); err != nil {
+		err = fmt.Errorf("%w: trying to read at offset %d: %v",
+			ErrCorruptIndex, off, err)
+		return
+	}
+	p.num = num
+	p.data = p.data[:cap(p.data)]
+	ix.pages[num] = ix.recent.PushFront(p)
+	return
+}
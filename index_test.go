@@ -0,0 +1,274 @@
+// index_test.go - test index.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestIndex(t *testing.T) {
+	words := []string{"Smith", "Smyth", "Schmidt", "knight", "night",
+		"Thompson", "Tomson", "Xavier", "pneumonia"}
+	metaph := NewMetaphMap(words, 6)
+	name := filepath.Join(t.TempDir(), "words.idx")
+	if err := metaph.WriteIndexFile(name); err != nil {
+		t.Fatal(err)
+	}
+	var hits, misses int
+	opts := &Options{Encoder: &Encoder{Hooks: &Hooks{
+		OnCacheHit:  func(string) { hits++ },
+		OnCacheMiss: func(string) { misses++ },
+	}}}
+	ix, err := OpenIndex(name, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ix.Close()
+	if ix.Len() != metaph.Len() {
+		t.Errorf("Len got: %d;  want: %d", ix.Len(), metaph.Len())
+	}
+	for _, word := range append(words, "knewmoanya", "zzz") {
+		got, err := ix.MatchWord(word)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := metaph.MatchWord(word)
+		sort.Strings(got)
+		sort.Strings(want)
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("%s got: %v;  want: %v", word, got, want)
+		}
+	}
+	if misses != 1 || hits == 0 {
+		t.Errorf("got: %d misses, %d hits;  want: 1 miss, some hits",
+			misses, hits)
+	}
+}
+
+func TestIndexCorrupt(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewMetaphMap([]string{"Smith", "night"}, 4).WriteIndex(&buf); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+	if _, err := ReadIndex(bytes.NewReader(b[:len(b)-1]), int64(len(b)-1),
+		nil); !errors.Is(err, ErrCorruptIndex) {
+		t.Errorf("short index got: %v;  want: %v", err, ErrCorruptIndex)
+	}
+	// a changed byte fails the checksum, when loaded or verified again
+	ix, err := ReadIndex(bytes.NewReader(b), int64(len(b)), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b[len(b)-1]++
+	if _, err = ReadIndex(bytes.NewReader(b), int64(len(b)),
+		nil); !errors.Is(err, ErrCorruptIndex) {
+		t.Errorf("changed byte got: %v;  want: %v", err, ErrCorruptIndex)
+	}
+	if err = ix.Verify(); !errors.Is(err, ErrCorruptIndex) {
+		t.Errorf("changed byte verified got: %v;  want: %v", err,
+			ErrCorruptIndex)
+	}
+	// a word length past the end of the last bucket, Smith's XMT, with a
+	// checksum to match
+	b[len(b)-1]--
+	b[len(b)-len("Smith")-1] = 100
+	h, _, err := parseIndexHeader(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.crc = h.sum(b[indexHeaderLen:])
+	copy(b, h.append(nil))
+	if ix, err = ReadIndex(bytes.NewReader(b), int64(len(b)), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err = ix.Verify(); err != nil {
+		t.Errorf("matching checksum got: %v;  want: <nil>", err)
+	}
+	if _, err = ix.MatchWord("Smith"); !errors.Is(err, ErrCorruptIndex) {
+		t.Errorf("bad word length got: %v;  want: %v", err, ErrCorruptIndex)
+	}
+}
+
+// eofReader is a ReaderAt that returns io.EOF with a read that ends at
+// the end of its input, as the io.ReaderAt contract allows.
+type eofReader struct{ b []byte }
+
+func (r eofReader) ReadAt(p []byte, off int64) (n int, err error) {
+	if off >= int64(len(r.b)) {
+		return 0, io.EOF
+	}
+	n = copy(p, r.b[off:])
+	if off+int64(n) == int64(len(r.b)) {
+		err = io.EOF
+	}
+	return
+}
+
+func TestIndexEOF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewMetaphMap([]string{"Smith", "Smyth"}, 4).WriteIndex(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, load := range []bool{false, true} {
+		ix, err := ReadIndex(eofReader{buf.Bytes()}, int64(buf.Len()), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if load {
+			if err = ix.LoadCodeTable(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		got, err := ix.MatchWord("Smith")
+		sort.Strings(got)
+		if fmt.Sprint(got) != "[Smith Smyth]" || err != nil {
+			t.Errorf("loaded %v got: %v, %v;  want: [Smith Smyth], <nil>",
+				load, got, err)
+		}
+	}
+}
+
+func TestIndexPages(t *testing.T) {
+	var words []string
+	for i := range 50000 {
+		var w []byte
+		for n := i + 26*26; n > 0; n /= 26 {
+			w = append(w, byte('a'+n%26))
+		}
+		words = append(words, string(w))
+	}
+	metaph := NewMetaphMap(words, 6)
+	var buf bytes.Buffer
+	if err := metaph.WriteIndex(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() <= indexPages*indexPageSize {
+		t.Fatalf("index is only %d bytes", buf.Len())
+	}
+	ix, err := ReadIndex(bytes.NewReader(buf.Bytes()), int64(buf.Len()), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < len(words); i += 97 {
+		word := words[i]
+		got, err := ix.MatchWord(word)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := metaph.MatchWord(word); len(got) != len(want) {
+			t.Fatalf("%s got: %v;  want: %v", word, got, want)
+		}
+	}
+	if ix.recent.Len() > indexPages {
+		t.Errorf("cached pages got: %d;  want: at most %d", ix.recent.Len(),
+			indexPages)
+	}
+}
+
+func TestLoadCodeTable(t *testing.T) {
+	words := []string{"Smith", "Smyth", "Schmidt", "knight", "night"}
+	metaph := NewMetaphMap(words, 6)
+	var buf bytes.Buffer
+	if err := metaph.WriteIndex(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var hits, misses []string
+	opts := &Options{Encoder: &Encoder{Hooks: &Hooks{
+		OnCacheHit:  func(key string) { hits = append(hits, key) },
+		OnCacheMiss: func(key string) { misses = append(misses, key) },
+	}}}
+	ix, err := ReadIndex(bytes.NewReader(buf.Bytes()), int64(buf.Len()), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ix.LoadCodeTable(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ix.buckets) != 0 {
+		t.Errorf("buckets loaded before lookup: %v", ix.buckets)
+	}
+	for range 2 {
+		got, err := ix.MatchWord("smith")
+		if err != nil {
+			t.Fatal(err)
+		}
+		sort.Strings(got)
+		if want := "[Schmidt Smith Smyth]"; fmt.Sprint(got) != want {
+			t.Errorf("got: %v;  want: %s", got, want)
+		}
+	}
+	if fmt.Sprint(misses) != "[SM0 XMT]" || fmt.Sprint(hits) != "[SM0 XMT]" {
+		t.Errorf("got misses: %v, hits: %v;  want: [SM0 XMT] for both",
+			misses, hits)
+	}
+	if len(ix.buckets) != 2 {
+		t.Errorf("got %d buckets loaded;  want: 2", len(ix.buckets))
+	}
+}
+
+func TestIndexVersion(t *testing.T) {
+	metaph := NewMetaphMap([]string{"Smith", "night"}, 4)
+	var buf bytes.Buffer
+	if err := metaph.WriteIndex(&buf); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+	for _, opts := range []*Options{
+		{Encoder: &Encoder{Version: Version2}},
+		{Encoder: &Encoder{Enhanced: Enhanced1}},
+		{Rhyme: true},
+	} {
+		_, err := ReadIndex(bytes.NewReader(b), int64(len(b)), opts)
+		if !errors.Is(err, ErrIncompatibleIndex) {
+			t.Errorf("%+v got: %v;  want: %v", opts, err, ErrIncompatibleIndex)
+		}
+	}
+	future := bytes.Clone(b)
+	future[len(indexMagic)] = indexFormat + 1
+	if _, err := ReadIndex(bytes.NewReader(future), int64(len(future)),
+		nil); !errors.Is(err, ErrIncompatibleIndex) {
+		t.Errorf("future format got: %v;  want: %v", err, ErrIncompatibleIndex)
+	}
+
+	// a format 1 index: maxLen and the lengths, then the same sections
+	var v1 []byte
+	for _, off := range []int{8, 24, 28, 32} {
+		v1 = append(v1, b[off:off+4]...)
+	}
+	v1 = append(v1, b[indexHeaderLen:]...)
+	if _, err := ReadIndex(bytes.NewReader(v1), int64(len(v1)),
+		nil); !errors.Is(err, ErrIncompatibleIndex) {
+		t.Errorf("format 1 got: %v;  want: %v", err, ErrIncompatibleIndex)
+	}
+	// a format 2 index: the header without the checksum
+	v2 := append(bytes.Clone(b[:indexV2HeaderLen]), b[indexHeaderLen:]...)
+	v2[len(indexMagic)] = 2
+	var migrated bytes.Buffer
+	for _, old := range [][]byte{v1, v2} {
+		migrated.Reset()
+		if err := MigrateIndex(bytes.NewReader(old), &migrated, nil); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(migrated.Bytes(), b) {
+			t.Errorf("migrated index differs from one written now")
+		}
+	}
+	migrated.Reset()
+	if err := MigrateIndex(bytes.NewReader(b), &migrated, nil); err != nil ||
+		!bytes.Equal(migrated.Bytes(), b) {
+		t.Errorf("current format got: %v, changed %v", err,
+			!bytes.Equal(migrated.Bytes(), b))
+	}
+	if err := MigrateIndex(bytes.NewReader(v1[:len(v1)-1]), &migrated,
+		nil); !errors.Is(err, ErrCorruptIndex) {
+		t.Errorf("short format 1 got: %v;  want: %v", err, ErrCorruptIndex)
+	}
+}
@@ -0,0 +1,94 @@
+// koelner.go - the Kölner Phonetik (Cologne phonetics) algorithm.
+// Created 2026-10-16 and placed in the public domain.
+//
+// Kölner Phonetik was published by Hans Joachim Postel in 1969.  It suits
+// German words and names better than Double Metaphone does.
+
+package metaphone
+
+import "strings"
+
+// Koelner returns the Kölner Phonetik code of word, a string of digits
+// such as "65752682" for "Müller-Lüdenscheidt".  Words that sound alike in
+// German have the same code.  Case and characters other than the letters
+// A-Z, Ä, Ö, Ü and ß are ignored.  Its codes cannot be compared with
+// DoubleMetaphone codes, so they should not be stored in a MetaphMap
+// with them; EncodeLang gives German codes that can.
+func Koelner(word string) string {
+	var letters []byte
+	for _, r := range strings.ToUpper(word) {
+		switch r {
+		case 'Ä':
+			r = 'A'
+		case 'Ö':
+			r = 'O'
+		case 'Ü':
+			r = 'U'
+		case 'ß':
+			r = 'S'
+		}
+		if 'A' <= r && r <= 'Z' {
+			letters = append(letters, byte(r))
+		}
+	}
+	at := func(i int) byte {
+		if i < 0 || i >= len(letters) {
+			return 0
+		}
+		return letters[i]
+	}
+	in := func(c byte, set string) bool {
+		return c != 0 && strings.IndexByte(set, c) >= 0
+	}
+	var raw []byte
+	for i, c := range letters {
+		prev, next := at(i-1), at(i+1)
+		switch {
+		case in(c, "AEIJOUY"):
+			raw = append(raw, '0')
+		case c == 'H':
+		case c == 'B', c == 'P' && next != 'H':
+			raw = append(raw, '1')
+		case in(c, "DT"):
+			if in(next, "CSZ") {
+				raw = append(raw, '8')
+			} else {
+				raw = append(raw, '2')
+			}
+		case in(c, "FVWP"):
+			raw = append(raw, '3')
+		case in(c, "GKQ"):
+			raw = append(raw, '4')
+		case c == 'C':
+			switch {
+			case i == 0 && in(next, "AHKLOQRUX"),
+				i > 0 && !in(prev, "SZ") && in(next, "AHKOQUX"):
+				raw = append(raw, '4')
+			default:
+				raw = append(raw, '8')
+			}
+		case c == 'X':
+			if in(prev, "CKQ") {
+				raw = append(raw, '8')
+			} else {
+				raw = append(raw, '4', '8')
+			}
+		case c == 'L':
+			raw = append(raw, '5')
+		case in(c, "MN"):
+			raw = append(raw, '6')
+		case c == 'R':
+			raw = append(raw, '7')
+		case in(c, "SZ"):
+			raw = append(raw, '8')
+		}
+	}
+	var code strings.Builder
+	for i, d := range raw {
+		if i > 0 && d == raw[i-1] || i > 0 && d == '0' {
+			continue
+		}
+		code.WriteByte(d)
+	}
+	return code.String()
+}
@@ -0,0 +1,153 @@
+// language.go - guess the language of a word and encode it accordingly.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import "strings"
+
+// Language is a language that words can be encoded for, named by its
+// BCP 47 primary language subtag.
+type Language string
+
+const (
+	// English words are encoded with DoubleMetaphone, which also suits
+	// many other languages.
+	English Language = "en"
+	// German words are encoded with Koelner by AutoLanguage, and by
+	// EncodeLang with DoubleMetaphone adjusted by German rules, such as
+	// W and V sounding alike.
+	German Language = "de"
+	// Spanish words are encoded with SpanishMetaphone by AutoLanguage,
+	// and by EncodeLang with DoubleMetaphone adjusted by Spanish rules,
+	// such as J and G before E or I sounding like H.
+	Spanish Language = "es"
+)
+
+// languageRules are the Rules that adjust DoubleMetaphone for a language.
+// They are tried after an Encoder's own Rules, so codes of all languages
+// are DoubleMetaphone codes and can be stored and matched together.  A
+// rule's secondary code is usually the code DoubleMetaphone gives the
+// letters, so a word encoded for its language still matches the same
+// word encoded without one.
+var languageRules = map[Language]Rules{
+	German: {
+		{Match: "SCH", Primary: "X"},
+		{Match: "CHS", Primary: "KS"},
+		{Match: "CH", Primary: "X", Secondary: "K"},
+		{Match: "TZ", Primary: "TS", Secondary: "S"},
+		{Match: "Z", Primary: "TS", Secondary: "S"},
+		{Match: "W", Primary: "F"},
+		{Match: "J", At: "start", Primary: "A", Secondary: "J"},
+		{Match: "PF", Primary: "F"},
+		{Match: "ß", Primary: "S"},
+		{Match: "Ä", At: "start", Primary: "A"},
+		{Match: "Ö", At: "start", Primary: "A"},
+		{Match: "Ü", At: "start", Primary: "A"},
+	},
+	Spanish: {
+		{Match: "J", Primary: "H", Secondary: "J"},
+		{Match: "GE", Primary: "H", Secondary: "J"},
+		{Match: "GI", Primary: "H", Secondary: "J"},
+		{Match: "H", Primary: "", Secondary: ""},
+		{Match: "V", Primary: "P"},
+		{Match: "LL", Primary: "L"},
+		{Match: "Z", Primary: "S"},
+	},
+}
+
+// languageNgrams weighs letter sequences that suggest a language.  "^"
+// marks the start of a word and "$" its end.
+var languageNgrams = map[Language]map[string]float64{
+	English: {
+		"th": 1.5, "wh": 1.5, "sh": 1, "ght": 2, "ph": 1, "ck": 0.5,
+		"ee": 1, "oo": 1, "ea": 0.8, "ou": 0.5, "w": 0.3, "k": 0.3,
+		"^wr": 1, "ing$": 1.5, "tion$": 1, "ly$": 1.5, "ness$": 1.5,
+		"y$": 0.5, "ton$": 1, "son$": 1,
+	},
+	German: {
+		"ä": 3, "ö": 3, "ü": 3, "ß": 4, "sch": 2, "tsch": 2, "tz": 1.5,
+		"cht": 2, "chs": 1.5, "pf": 1.5, "dt": 1.5, "äu": 2, "eu": 0.7,
+		"ei": 0.5, "ie": 0.3, "^zw": 2, "^ge": 0.5, "ung$": 1.5,
+		"mann$": 1.5, "berg$": 1.5, "stein$": 1.5, "heit$": 2,
+		"keit$": 2, "lich$": 2, "z": 0.5,
+	},
+	Spanish: {
+		"ñ": 4, "á": 2, "é": 1.5, "í": 2, "ó": 2, "ú": 2, "ll": 0.5,
+		"rr": 1, "que": 0.7, "gue": 0.5, "ja": 1, "jo": 1, "ju": 0.7,
+		"ción$": 3, "cion$": 2, "ez$": 2, "dad$": 2, "ía$": 1, "ito$": 1.5,
+		"ita$": 1.5, "illo$": 1.5, "ado$": 1, "ero$": 1, "os$": 1,
+		"as$": 0.7, "o$": 0.7,
+	},
+}
+
+// DetectLanguage guesses the language of word from the letter sequences
+// in it, such as "sch" for German and "ñ" or a final "ez" for Spanish.  It
+// returns English when there is no better guess.  A single word gives
+// little evidence, so the guess is often wrong for short or common words;
+// it is meant for routing the words of a multilingual dictionary to a
+// suitable encoder, not for identifying languages in general.
+func DetectLanguage(word string) Language {
+	w := "^" + strings.ToLower(word) + "$"
+	best, bestScore := English, 0.0
+	scores := make(map[Language]float64)
+	for lang, ngrams := range languageNgrams {
+		for ngram, weight := range ngrams {
+			scores[lang] += weight * float64(strings.Count(w, ngram))
+		}
+	}
+	for _, lang := range []Language{German, Spanish} {
+		if s := scores[lang]; s >= 1 && s > scores[English] && s > bestScore {
+			best, bestScore = lang, s
+		}
+	}
+	return best
+}
+
+// encodeWord returns the codes of word, a single word, for lang.  If lang
+// is empty and enc.AutoLanguage is true, word is encoded with the encoder
+// for the language detected for it.  Acronyms are spelled out if
+// enc.Acronyms is true.
+func (enc *Encoder) encodeWord(word string, lang Language) (metaph,
+	metaph2 string) {
+	if enc.Acronyms && isAcronym(word) {
+		return enc.encodeAcronym(word)
+	}
+	if lang == "" && enc.AutoLanguage {
+		return enc.encodeAuto(word, DetectLanguage(word))
+	}
+	return enc.encodeLang(word, lang)
+}
+
+// encodeAuto returns the codes of word with the encoder for lang.  Only
+// DoubleMetaphone makes secondary codes.
+func (enc *Encoder) encodeAuto(word string, lang Language) (metaph,
+	metaph2 string) {
+	switch lang {
+	case German:
+		return enc.truncate(Koelner(word)), ""
+	case Spanish:
+		return SpanishMetaphone(word, enc.MaxLen), ""
+	}
+	return doubleMetaphone(word, enc.MaxLen, enc)
+}
+
+// parseLanguage returns the Language of BCP 47 language tag tag, such as
+// Spanish for "es-MX", or "" if tag is empty.
+func parseLanguage(tag string) Language {
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return Language(strings.ToLower(tag))
+}
+
+// encodeLang returns the codes of word with DoubleMetaphone and the
+// languageRules of lang.
+func (enc *Encoder) encodeLang(word string, lang Language) (metaph,
+	metaph2 string) {
+	if rules := languageRules[lang]; len(rules) > 0 {
+		e := *enc
+		e.Rules = append(enc.Rules[:len(enc.Rules):len(enc.Rules)], rules...)
+		enc = &e
+	}
+	return doubleMetaphone(word, enc.MaxLen, enc)
+}
@@ -0,0 +1,115 @@
+// language_test.go - test language.go, koelner.go and spanish.go.
+// This file is public domain.
+
+package metaphone
+
+import "testing"
+
+func TestKoelner(t *testing.T) {
+	tests := []struct{ in, out string }{
+		{"Müller-Lüdenscheidt", "65752682"},
+		{"Wikipedia", "3412"},
+		{"Breschnew", "17863"},
+		{"Meyer", "67"},
+		{"Maier", "67"},
+		{"Christoph", "47823"},
+		{"Xaver", "4837"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Koelner(tt.in); got != tt.out {
+			t.Errorf("%s got: %s;  want: %s", tt.in, got, tt.out)
+		}
+	}
+}
+
+func TestSpanishMetaphone(t *testing.T) {
+	tests := []struct{ in, out string }{
+		{"Jiménez", "JMNZ"},
+		{"Giménez", "JMNZ"},
+		{"Chávez", "XBZ"},
+		{"Sánchez", "SNXZ"},
+		{"Guillermo", "GYRM"},
+		{"Quijote", "KJT"},
+		{"Hernández", "ERNNDZ"},
+		{"España", "ESPNY"},
+		{"Stefan", "ESTFN"},
+		{"acción", "AKZN"},
+		{"Vargas", "BRGS"},
+	}
+	for _, tt := range tests {
+		if got := SpanishMetaphone(tt.in, 6); got != tt.out {
+			t.Errorf("%s got: %s;  want: %s", tt.in, got, tt.out)
+		}
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		word string
+		lang Language
+	}{
+		{"Müller", German}, {"Schmidt", German}, {"Zwickau", German},
+		{"Jiménez", Spanish}, {"Peña", Spanish}, {"Gutiérrez", Spanish},
+		{"Smith", English}, {"Washington", English}, {"knight", English},
+	}
+	for _, tt := range tests {
+		if got := DetectLanguage(tt.word); got != tt.lang {
+			t.Errorf("%s got: %s;  want: %s", tt.word, got, tt.lang)
+		}
+	}
+	enc := &Encoder{MaxLen: 6, AutoLanguage: true}
+	if m, m2 := enc.Encode("Müller"); m != "657" || m2 != "" {
+		t.Errorf("Müller got: %s, %s;  want: 657", m, m2)
+	}
+	if m, _ := enc.Encode("Gutiérrez"); m != "GTRZ" {
+		t.Errorf("Gutiérrez got: %s;  want: GTRZ", m)
+	}
+	if m, m2 := enc.Encode("Smith"); m != "SM0" || m2 != "XMT" {
+		t.Errorf("Smith got: %s, %s;  want: SM0, XMT", m, m2)
+	}
+}
+
+func TestEncodeLang(t *testing.T) {
+	enc := NewEncoder(6)
+	tests := []struct {
+		word, lang, m, m2 string
+	}{
+		{"Juan", "es-MX", "HN", "JN"},
+		{"Juan", "en", "JN", "AN"},
+		{"Vargas", "es", "PRKS", ""},
+		{"Wagner", "de-AT", "FKNR", ""},
+		{"Vagner", "DE", "FNR", "FKNR"},
+		{"Wagner", "", "AKNR", "FKNR"},
+		{"Schmidt", "de", "XMT", ""},
+		{"Jung", "de", "ANK", "JNK"},
+	}
+	for _, tt := range tests {
+		if m, m2 := enc.EncodeLang(tt.word, tt.lang); m != tt.m || m2 != tt.m2 {
+			t.Errorf("%s %s got: %s, %s;  want: %s, %s",
+				tt.word, tt.lang, m, m2, tt.m, tt.m2)
+		}
+	}
+	// Codes for a language are DoubleMetaphone codes, so they match the
+	// codes of sound-alikes encoded without a language.
+	for _, pair := range [][3]string{{"Jiménez", "es", "Himenez"},
+		{"Vargas", "es", "Bargas"}, {"Wagner", "de", "Wagner"},
+		{"Jung", "de", "Young"}, {"Juan", "es", "Juan"}} {
+		m, m2 := enc.EncodeLang(pair[0], pair[1])
+		n, n2 := enc.Encode(pair[2])
+		if compareCodes(m, m2, n, n2) == NoMatch {
+			t.Errorf("%s (%s) does not match %s", pair[0], pair[1], pair[2])
+		}
+	}
+}
+
+func TestEncodeLangMultibyte(t *testing.T) {
+	for _, v := range []AlgorithmVersion{Version1, Version2} {
+		enc := &Encoder{MaxLen: 6, Version: v}
+		for _, lang := range []string{"de", "es", "en", ""} {
+			for _, word := range []string{"ＡＢＣ", "Größenwahn", "Muñoz"} {
+				enc.EncodeLang(word, lang)
+			}
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// matcher.go - serve matches from a dictionary that can be replaced.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Matcher matches words against a MetaphMap, its active dictionary, that
+// can be replaced while it is in use, so a long-running service can roll
+// out a new vocabulary without downtime.  Each MatchWord sees either the
+// old or the new dictionary, never a mix.  A Matcher is safe for
+// concurrent use.
+type Matcher struct {
+	mu     sync.RWMutex
+	metaph *MetaphMap
+}
+
+// NewMatcher returns a Matcher whose active dictionary is metaph.
+func NewMatcher(metaph *MetaphMap) *Matcher {
+	return &Matcher{metaph: metaph}
+}
+
+// MatchWord returns all words in m's active dictionary that sound like
+// word, as (*MetaphMap).MatchWord does.
+func (m *Matcher) MatchWord(word string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.metaph.MatchWord(word)
+}
+
+// MetaphMap returns m's active dictionary.  It must not be changed while
+// m uses it, except through m's Add and RemoveWord.
+func (m *Matcher) MetaphMap() *MetaphMap {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.metaph
+}
+
+// Swap makes newDict m's active dictionary and returns the one it
+// replaces.  Lookups already under way finish with the old dictionary.
+func (m *Matcher) Swap(newDict *MetaphMap) (old *MetaphMap) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	old, m.metaph = m.metaph, newDict
+	return
+}
+
+// Reload builds a new dictionary from the word list of src, with the
+// maxLen and Options of m's active dictionary, and swaps it in.  m keeps
+// serving from the active dictionary while the new one is built; if it
+// cannot be built, the error is returned and the active dictionary is
+// kept.
+func (m *Matcher) Reload(src Source) (err error) {
+	cur := m.MetaphMap()
+	b := NewBuilder()
+	if cur != nil {
+		b.maxLen, b.opts = cur.maxlen, cur.opts
+	}
+	var metaph *MetaphMap
+	if metaph, err = b.FromSource(src).Build(); err != nil {
+		return
+	}
+	m.Swap(metaph)
+	return
+}
+
+// Add adds word to m's active dictionary, tagged with meta, which can be
+// nil, as (*MetaphMap).Add does.  If m has no active dictionary, Add
+// makes an empty one with the maxLen and Options of NewBuilder first.
+func (m *Matcher) Add(word string, meta Metadata) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.metaph == nil {
+		b := NewBuilder()
+		m.metaph = newMetaphMap(b.maxLen, &b.opts)
+	}
+	return m.metaph.Add(word, meta)
+}
+
+// RemoveWord removes word from m's active dictionary as
+// (*MetaphMap).RemoveWord does.  It returns false if m has no active
+// dictionary.
+func (m *Matcher) RemoveWord(word string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.metaph.RemoveWord(word)
+}
+
+// snapshot is the JSON form of a dictionary written by Snapshot.
+type snapshot struct {
+	MaxLen int             `json:"maxLen"`
+	Words  []snapshotEntry `json:"words"`
+}
+
+// snapshotEntry is a word of a snapshot.
+type snapshotEntry struct {
+	Word  string     `json:"word"`
+	Count int        `json:"count"`
+	Meta  []Metadata `json:"meta,omitempty"`
+}
+
+// Snapshot returns a JSON copy of m's active dictionary, including words
+// added since it was made, with their frequencies and Metadata, for
+// Restore to load after a restart.  The copy is taken when Snapshot is
+// called; lookups and changes continue while it is read.
+func (m *Matcher) Snapshot() io.ReadCloser {
+	m.mu.RLock()
+	var s snapshot
+	if m.metaph != nil {
+		s.MaxLen = m.metaph.maxlen
+		for _, w := range m.metaph.Words() {
+			s.Words = append(s.Words, snapshotEntry{Word: w,
+				Count: m.metaph.freq[w], Meta: m.metaph.meta[w]})
+		}
+	}
+	b, _ := json.Marshal(s) // can't fail: strings, ints and string maps
+	m.mu.RUnlock()
+	return io.NopCloser(bytes.NewReader(b))
+}
+
+// Restore reads a dictionary written by Snapshot from r and swaps it in.
+// The words are encoded with the Options of m's active dictionary and the
+// maxLen they were saved with.  If r cannot be read or decoded, the error
+// wraps ErrDictionaryFormat and the active dictionary is kept.
+func (m *Matcher) Restore(r io.Reader) (err error) {
+	var s snapshot
+	if err = json.NewDecoder(r).Decode(&s); err != nil {
+		err = fmt.Errorf("%w: trying to read snapshot: %v",
+			ErrDictionaryFormat, err)
+		return
+	}
+	var opts *Options
+	if cur := m.MetaphMap(); cur != nil {
+		opts = &cur.opts
+	}
+	metaph := newMetaphMap(s.MaxLen, opts)
+	for _, e := range s.Words {
+		stored, ok := metaph.add(e.Word)
+		if !ok {
+			continue
+		}
+		metaph.freq[stored] = e.Count
+		if len(e.Meta) > 0 {
+			if metaph.meta == nil {
+				metaph.meta = make(map[string][]Metadata)
+			}
+			metaph.meta[stored] = append(metaph.meta[stored], e.Meta...)
+		}
+	}
+	m.Swap(metaph)
+	return
+}
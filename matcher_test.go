@@ -0,0 +1,101 @@
+// matcher_test.go - test matcher.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestMatcher(t *testing.T) {
+	old, _ := NewBuilder().MaxLen(6).Case(CaseLower).Words("Smith").Build()
+	m := NewMatcher(old)
+	if got := m.MatchWord("smyth"); len(got) != 1 {
+		t.Errorf("got: %v;  want: [smith]", got)
+	}
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				if got := m.MatchWord("smith"); len(got) == 0 {
+					t.Errorf("got no matches during reload")
+					return
+				}
+			}
+		}()
+	}
+	src := NewSource("words", func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("Smyth\nSCHMIDT\n")), nil
+	})
+	if err := m.Reload(src); err != nil {
+		t.Fatal(err)
+	}
+	wg.Wait()
+
+	cur := m.MetaphMap()
+	if cur == old || cur.maxlen != 6 || len(cur.MatchWord("smith")) != 2 {
+		t.Errorf("reloaded dictionary got: %v", cur.Words())
+	}
+	if got := cur.Words(); got[0] != "schmidt" {
+		t.Errorf("reloaded dictionary lost its CasePolicy: %v", got)
+	}
+	if prev := m.Swap(old); prev != cur || m.MetaphMap() != old {
+		t.Errorf("Swap did not swap")
+	}
+	bad := NewSource("none", func() (io.ReadCloser, error) {
+		return nil, io.ErrUnexpectedEOF
+	})
+	if err := m.Reload(bad); err == nil || m.MetaphMap() != old {
+		t.Errorf("failed reload got: %v, replaced %v", err, m.MetaphMap() != old)
+	}
+
+	empty := NewMatcher(nil)
+	if empty.RemoveWord("Smith") {
+		t.Errorf("RemoveWord without a dictionary got: true;  want: false")
+	}
+	if !empty.Add("Smith", nil) || len(empty.MatchWord("Smyth")) != 1 {
+		t.Errorf("Add without a dictionary got: %v", empty.MetaphMap().Words())
+	}
+	if !empty.RemoveWord("Smith") || empty.MetaphMap().Len() != 0 {
+		t.Errorf("RemoveWord after Add got: %v", empty.MetaphMap().Words())
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	metaph, _ := NewBuilder().MaxLen(6).Case(CaseFold).
+		Words("Smith", "smith", "Jones").Build()
+	m := NewMatcher(metaph)
+	m.Add("Smyth", Metadata{"source": "runtime"})
+	m.RemoveWord("Jones")
+	rc := m.Snapshot()
+	b, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored := NewMatcher(NewMetaphMap(nil, 4))
+	if err = restored.Restore(strings.NewReader(string(b))); err != nil {
+		t.Fatal(err)
+	}
+	got := restored.MetaphMap()
+	if got.maxlen != 6 || fmt.Sprint(got.Words()) != "[Smith Smyth]" {
+		t.Errorf("got maxLen %d, words %v;  want 6, [Smith Smyth]",
+			got.maxlen, got.Words())
+	}
+	if got.freq["Smith"] != 2 || got.Metadata("Smyth")[0]["source"] != "runtime" {
+		t.Errorf("got freq %v, meta %v", got.freq, got.meta)
+	}
+	if err = restored.Restore(strings.NewReader("{")); !errors.Is(err,
+		ErrDictionaryFormat) || restored.MetaphMap() != got {
+		t.Errorf("bad snapshot got: %v;  want: %v", err, ErrDictionaryFormat)
+	}
+}
@@ -0,0 +1,64 @@
+// metadata.go - tag the words of a MetaphMap and filter matches by tag.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import "maps"
+
+// Metadata tags a word of a MetaphMap with information such as its
+// language, source or part of speech, e.g. {"lang": "fr", "source":
+// "customer-42"}.
+type Metadata map[string]string
+
+// Add adds word to metaph, as the word list of NewMetaphMap is added, and
+// tags it with meta, which can be nil.  A word added more than once, for
+// example from the dictionaries of two locales, keeps each Metadata it was
+// added with.  Add returns false if word was left out as a stop word or as
+// too short.
+func (metaph *MetaphMap) Add(word string, meta Metadata) bool {
+	if metaph == nil {
+		return false
+	}
+	stored, ok := metaph.add(word)
+	if ok && meta != nil {
+		if metaph.meta == nil {
+			metaph.meta = make(map[string][]Metadata)
+		}
+		metaph.meta[stored] = append(metaph.meta[stored], maps.Clone(meta))
+	}
+	return ok
+}
+
+// Metadata returns the Metadata word was added with, in the order added.
+// word must be as stored, such as a word returned by MatchWord.
+func (metaph *MetaphMap) Metadata(word string) []Metadata {
+	if metaph == nil {
+		return nil
+	}
+	return metaph.meta[word]
+}
+
+// MatchWordFunc returns the words in metaph that sound like word and for
+// which keep returns true for at least one of their Metadata.  keep is
+// called with nil for a word that has no Metadata.  For example, to
+// suggest only French words:
+//
+//	metaph.MatchWordFunc(word, func(w string, m metaphone.Metadata) bool {
+//		return m["lang"] == "fr"
+//	})
+func (metaph *MetaphMap) MatchWordFunc(word string,
+	keep func(word string, meta Metadata) bool) (output []string) {
+	for _, w := range metaph.MatchWord(word) {
+		metas := metaph.meta[w]
+		if len(metas) == 0 {
+			metas = []Metadata{nil}
+		}
+		for _, m := range metas {
+			if keep(w, m) {
+				output = append(output, w)
+				break
+			}
+		}
+	}
+	return
+}
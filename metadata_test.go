@@ -0,0 +1,40 @@
+// metadata_test.go - test metadata.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func TestMetadata(t *testing.T) {
+	metaph := NewMetaphMap(nil, 4)
+	metaph.Add("chat", Metadata{"lang": "en"})
+	metaph.Add("chat", Metadata{"lang": "fr"})
+	metaph.Add("shut", Metadata{"lang": "en"})
+	metaph.Add("chatte", Metadata{"lang": "fr"})
+	metaph.Add("shot", nil)
+	lang := func(l string) func(string, Metadata) bool {
+		return func(w string, m Metadata) bool { return m["lang"] == l }
+	}
+	tests := []struct {
+		keep func(string, Metadata) bool
+		want string
+	}{
+		{lang("fr"), "[chat chatte]"},
+		{lang("en"), "[chat shut]"},
+		{lang(""), "[shot]"},
+	}
+	for _, tt := range tests {
+		got := metaph.MatchWordFunc("shat", tt.keep)
+		sort.Strings(got)
+		if fmt.Sprint(got) != tt.want {
+			t.Errorf("got: %v;  want: %s", got, tt.want)
+		}
+	}
+	if got := fmt.Sprint(metaph.Metadata("chat")); got != "[map[lang:en] map[lang:fr]]" {
+		t.Errorf("got: %s", got)
+	}
+}
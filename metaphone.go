@@ -20,7 +20,23 @@
 // Package metaphone is an open source implementation of Double Metaphone.
 package metaphone
 
-import "strings"
+import (
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// runeBufs holds buffers for the upper-cased, padded runes of words being
+// encoded, reused between calls.
+var runeBufs = sync.Pool{New: func() any {
+	b := make([]rune, 0, 32)
+	return &b
+}}
+
+// vowelTable tells which ASCII letters IsVowel treats as vowels.
+var vowelTable = [utf8.RuneSelf]bool{'A': true, 'E': true, 'I': true,
+	'O': true, 'U': true, 'Y': true}
 
 // DoubleMetaphone returns primary and secondary codes for word.
 // Metaph and metaph2 are each limited to maxlength characters.
@@ -43,59 +59,140 @@ import "strings"
 //	}
 //	// ...
 func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
+	return doubleMetaphone(word, maxlength, nil)
+}
+
+// doubleMetaphone is DoubleMetaphone with the Version, Rules, Enhanced
+// rules and Vowels setting of enc, which can be nil.
+func doubleMetaphone(word string, maxlength int,
+	enc *Encoder) (metaph, metaph2 string) {
+	res := results.Get().(*Result)
+	defer results.Put(res)
+	res.doubleMetaphone(word, maxlength, enc)
+	return string(res.Metaph), string(res.Metaph2)
+}
+
+// doubleMetaphone is doubleMetaphone that puts the codes in res, reusing
+// its buffers.
+func (res *Result) doubleMetaphone(word string, maxlength int,
+	enc *Encoder) {
 	const pad = "     " // 5 spaces
 
+	var rules Rules
+	var onRule func(pos int, rule string, primary, secondary string)
+	version := Version1
+	vowels := false
+	if enc != nil {
+		rules = enc.rules()
+		version = max(enc.Version, Version1)
+		vowels = enc.Vowels
+		if enc.Hooks != nil {
+			onRule = enc.Hooks.OnRule
+		}
+	}
+
+	// becomes metaph, metaph2
+	primary, secondary := res.Metaph[:0], res.Metaph2[:0]
+	res.Metaph, res.Metaph2 = primary, secondary
+
 	length := len(word)
 	if length < 1 {
 		return
 	}
+	if version >= Version2 {
+		length = utf8.RuneCountInString(word)
+	}
 	if maxlength < 1 {
 		maxlength = 4
 	}
 
 	var current = 0
-	last := length - 1                     //zero based index
-	var primary, secondary strings.Builder // becomes metaph, metaph2
-
-	word = strings.ToUpper(word)
-
-	// pad with spaces at end
-	word += pad
-	rword := []rune(word)
+	last := length - 1 //zero based index
+
+	// upper-case word and pad with spaces at end, in a reused buffer
+	buf := runeBufs.Get().(*[]rune)
+	defer runeBufs.Put(buf)
+	rword := (*buf)[:0]
+	for _, r := range word {
+		rword = append(rword, unicode.ToUpper(r))
+	}
+	for range len(pad) {
+		rword = append(rword, ' ')
+	}
+	*buf = rword
 	rwordLen := len(rword)
 	alternate := false
 
-	// Found determines whether s is in word.
+	// Found determines whether s is in rword.
 	Found := func(s string) bool {
-		return strings.Contains(word, s)
+	next:
+		for i := range rwordLen - len(s) + 1 {
+			for j := range len(s) {
+				if rword[i+j] != rune(s[j]) {
+					continue next
+				}
+			}
+			return true
+		}
+		return false
 	}
 
+	// computed once per word rather than on each call of SlavoGermanic
+	slavoGermanic := Found("W") || Found("K") || Found("CZ") // never reached: || Found("WITZ")
 	SlavoGermanic := func() bool {
-		return Found("W") || Found("K") || Found("CZ") // never reached: || Found("WITZ")
+		return slavoGermanic
 	}
 
-	// MetaphAdd adds a string to primary and secondary.  Call it with 1 or 2
-	// arguments.  The first argument is appended to primary (and to
-	// secondary if a second argument is empty or not provided).  Any second,
-	// non-empty argument is appended to secondary.
-	MetaphAdd := func(s ...string) {
-		if len(s) < 1 || len(s) > 2 {
-			panic("MetaphAdd requires one or two arguments")
+	// Write appends code to b, clipped so that b holds at most maxlength
+	// characters; characters past maxlength would be cut off anyway.
+	Write := func(b *[]byte, code string) {
+		if n := maxlength - len(*b); len(code) > n {
+			code = code[:max(n, 0)]
 		}
-		main := s[0]
-		primary.WriteString(main)
-		if len(s) == 1 {
-			secondary.WriteString(main)
-		} else {
-			alt := s[1]
-			if len(alt) > 0 {
-				alternate = true
-				if alt[0] != ' ' {
-					secondary.WriteString(alt)
-				}
-			} else if len(main) > 0 && main[0] != ' ' {
-				secondary.WriteString(main)
-			}
+		*b = append(*b, code...)
+	}
+
+	// The secondary code matches the primary code until a rule first
+	// gives them different codes, which most words never do, so nothing
+	// is written to secondary until then; diverged tells if it has been.
+	diverged := false
+	Diverge := func() {
+		if !diverged {
+			diverged = true
+			secondary = append(secondary, primary...)
+		}
+	}
+	SecondaryLen := func() int {
+		if diverged {
+			return len(secondary)
+		}
+		return len(primary)
+	}
+
+	// MetaphAdd appends main to primary and secondary.
+	MetaphAdd := func(main string) {
+		Write(&primary, main)
+		if diverged {
+			Write(&secondary, main)
+		}
+	}
+
+	// MetaphAddAlt appends main to primary.  A non-empty alt is appended
+	// to secondary, unless it starts with a space, and gives the word an
+	// alternate code; otherwise main is appended to secondary, unless it
+	// is empty or starts with a space.
+	MetaphAddAlt := func(main, alt string) {
+		if len(alt) > 0 || len(main) > 0 && main[0] == ' ' {
+			Diverge()
+		}
+		Write(&primary, main)
+		if len(alt) > 0 {
+			alternate = true
+			if alt[0] != ' ' {
+				Write(&secondary, alt)
+			}
+		} else if diverged && len(main) > 0 && main[0] != ' ' {
+			Write(&secondary, main)
 		}
 	}
 
@@ -113,43 +210,93 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 		if at < 0 || at >= rwordLen {
 			return false
 		}
-		return strings.ContainsRune("AEIOUY", GetAt(at))
+		r := rword[at]
+		return r < utf8.RuneSelf && vowelTable[r]
 	}
 
 	// StringAt determines if any of a list of string arguments appear
-	// in rword at start and length long.
+	// in rword at start and length long.  The arguments, all ASCII, are
+	// compared rune by rune with rword in place, rather than with a
+	// string made from rword on each call, which was the allocation that
+	// profiles showed.  The argument lists are not made into static
+	// tables: they do not escape, so each call's list is built on the
+	// stack without an allocation.
 	StringAt := func(start, length int, s ...string) bool {
 		if start < 0 || (start+length) >= rwordLen {
 			return false
 		}
-		target := string(rword[start : start+length])
-		for i := 0; i < len(s); i++ {
-			if s[i] == target {
-				return true
+		window := rword[start : start+length]
+	next:
+		for _, t := range s {
+			if len(t) != length {
+				continue
+			}
+			for i, r := range window {
+				if rune(t[i]) != r {
+					continue next
+				}
 			}
+			return true
 		}
 		return false
 	}
 
+	// Fire calls onRule, if set, for the rule that consumed the letters
+	// from start to current and added to primary and secondary from
+	// lengths p and s on.
+	Fire := func(start int, rule string, p, s int) {
+		if onRule == nil {
+			return
+		}
+		if len(rule) == 0 {
+			rule = strings.TrimRight(string(rword[start:current]), " ")
+		}
+		second := primary
+		if diverged {
+			second = secondary
+		}
+		onRule(start, rule, string(primary[p:]), string(second[s:]))
+	}
+
 	//skip these when at start of word
 	if StringAt(0, 2, "GN", "KN", "PN", "WR", "PS") {
 		current += 1
+		Fire(0, "initial "+string(rword[:2]), 0, 0)
 	}
 
 	//Initial 'X' is pronounced 'Z' e.g. 'Xavier'
 	if GetAt(0) == 'X' {
 		MetaphAdd("S") //'Z' maps to 'S'
 		current += 1
+		Fire(0, "initial X", 0, 0)
 	}
 
 	///////////main loop//////////////////////////
 	for current < length &&
-		(primary.Len() < maxlength || secondary.Len() < maxlength) {
+		(len(primary) < maxlength || SecondaryLen() < maxlength) {
+		start, p, s := current, len(primary), SecondaryLen()
+		if r, n := rules.match(rword, current, last); n > 0 {
+			if len(r.Secondary) > 0 {
+				MetaphAddAlt(r.Primary, r.Secondary)
+			} else {
+				MetaphAdd(r.Primary)
+			}
+			current += n
+			if r.enhanced {
+				Fire(start, "enhanced "+r.Match, p, s)
+			} else {
+				Fire(start, "override "+r.Match, p, s)
+			}
+			continue
+		}
 		switch GetAt(current) {
 		case 'A', 'E', 'I', 'O', 'U', 'Y':
 			if current == 0 {
 				//all init vowels now map to 'A'
 				MetaphAdd("A")
+			} else if vowels && !IsVowel(current-1) {
+				// internal vowels, each run folded to one 'A'
+				MetaphAdd("A")
 			}
 			current += 1
 		case 'B':
@@ -193,7 +340,7 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 			if StringAt(current, 2, "CH") {
 				//find 'michael'
 				if current > 0 && StringAt(current, 4, "CHAE") {
-					MetaphAdd("K", "X")
+					MetaphAddAlt("K", "X")
 					current += 2
 					break
 				}
@@ -223,7 +370,7 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 							//e.g., "McHugh"
 							MetaphAdd("K")
 						} else {
-							MetaphAdd("X", "K")
+							MetaphAddAlt("X", "K")
 						}
 					} else {
 						MetaphAdd("X")
@@ -234,7 +381,7 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 			}
 			//e.g, 'czerny'
 			if StringAt(current, 2, "CZ") && !StringAt((current-2), 4, "WICZ") {
-				MetaphAdd("S", "X")
+				MetaphAddAlt("S", "X")
 				current += 2
 				break
 			}
@@ -275,7 +422,7 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 			if StringAt(current, 2, "CI", "CE", "CY") {
 				//italian vs. english
 				if StringAt(current, 3, "CIO", "CIE", "CIA") {
-					MetaphAdd("S", "X")
+					MetaphAddAlt("S", "X")
 				} else {
 					MetaphAdd("S")
 				}
@@ -368,12 +515,12 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 
 			if GetAt(current+1) == 'N' {
 				if current == 1 && IsVowel(0) && !SlavoGermanic() {
-					MetaphAdd("KN", "N")
+					MetaphAddAlt("KN", "N")
 				} else {
 					//not e.g. 'cagney'
 					if !StringAt((current+2), 2, "EY") &&
 						(GetAt(current+1) != 'Y') && !SlavoGermanic() {
-						MetaphAdd("N", "KN")
+						MetaphAddAlt("N", "KN")
 					} else {
 						MetaphAdd("KN")
 					}
@@ -384,7 +531,7 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 
 			//'tagliaro'
 			if StringAt((current+1), 2, "LI") && !SlavoGermanic() {
-				MetaphAdd("KL", "L")
+				MetaphAddAlt("KL", "L")
 				current += 2
 				break
 			}
@@ -393,7 +540,7 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 			if current == 0 &&
 				((GetAt(current+1) == 'Y') ||
 					StringAt((current+1), 2, "ES", "EP", "EB", "EL", "EY", "IB", "IL", "IN", "IE", "EI", "ER")) {
-				MetaphAdd("K", "J")
+				MetaphAddAlt("K", "J")
 				current += 2
 				break
 			}
@@ -403,7 +550,7 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 				!StringAt(0, 6, "DANGER", "RANGER", "MANGER") &&
 				!StringAt(current-1, 1, "E", "I") &&
 				!StringAt(current-1, 3, "RGY", "OGY") {
-				MetaphAdd("K", "J")
+				MetaphAddAlt("K", "J")
 				current += 2
 				break
 			}
@@ -420,7 +567,7 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 					if StringAt((current + 1), 4, "IER ") {
 						MetaphAdd("J")
 					} else {
-						MetaphAdd("J", "K")
+						MetaphAddAlt("J", "K")
 					}
 				}
 				current += 2
@@ -447,23 +594,23 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 				if ((current == 0) && (GetAt(current+4) == ' ')) || StringAt(0, 4, "SAN ") {
 					MetaphAdd("H")
 				} else {
-					MetaphAdd("J", "H")
+					MetaphAddAlt("J", "H")
 				}
 				current += 1
 				break
 			}
 
 			if current == 0 && !StringAt(current, 4, "JOSE") {
-				MetaphAdd("J", "A") //Yankelovich/Jankelowicz
+				MetaphAddAlt("J", "A") //Yankelovich/Jankelowicz
 			} else {
 				//spanish pron. of e.g. 'bajador'
 				if IsVowel(current-1) &&
 					!SlavoGermanic() &&
 					((GetAt(current+1) == 'A') || (GetAt(current+1) == 'O')) {
-					MetaphAdd("J", "H")
+					MetaphAddAlt("J", "H")
 				} else {
 					if current == last {
-						MetaphAdd("J", " ")
+						MetaphAddAlt("J", " ")
 					} else {
 						if !StringAt((current+1), 1, "L", "T", "K", "S", "N", "M", "B", "Z") &&
 							!StringAt((current-1), 1, "S", "K", "L") {
@@ -491,7 +638,7 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 					StringAt((current-1), 4, "ILLO", "ILLA", "ALLE")) ||
 					((StringAt((last-1), 2, "AS", "OS") || StringAt(last, 1, "A", "O")) &&
 						StringAt((current-1), 4, "ALLE")) {
-					MetaphAdd("L", " ")
+					MetaphAddAlt("L", " ")
 					current += 2
 					break
 				}
@@ -545,7 +692,7 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 			if current == last && !SlavoGermanic() &&
 				StringAt((current-2), 2, "IE") &&
 				!StringAt((current-4), 2, "ME", "MA") {
-				MetaphAdd("", "R")
+				MetaphAddAlt("", "R")
 			} else {
 				MetaphAdd("R")
 			}
@@ -564,7 +711,7 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 
 			//special case 'sugar-'
 			if (current == 0) && StringAt(current, 5, "SUGAR") {
-				MetaphAdd("X", "S")
+				MetaphAddAlt("X", "S")
 				current += 1
 				break
 			}
@@ -583,7 +730,7 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 			//italian & armenian
 			if StringAt(current, 3, "SIO", "SIA") || StringAt(current, 4, "SIAN") {
 				if !SlavoGermanic() {
-					MetaphAdd("S", "X")
+					MetaphAddAlt("S", "X")
 				} else {
 					MetaphAdd("S")
 				}
@@ -596,7 +743,7 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 			if current == 0 &&
 				StringAt((current+1), 1, "M", "N", "L", "W") ||
 				StringAt((current+1), 1, "Z") {
-				MetaphAdd("S", "X")
+				MetaphAddAlt("S", "X")
 				if StringAt((current + 1), 1, "Z") {
 					current += 2
 				} else {
@@ -612,14 +759,14 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 					if StringAt((current + 3), 2, "OO", "ER", "EN", "UY", "ED", "EM") {
 						//'schermerhorn', 'schenker'
 						if StringAt((current + 3), 2, "ER", "EN") {
-							MetaphAdd("X", "SK")
+							MetaphAddAlt("X", "SK")
 						} else {
 							MetaphAdd("SK")
 						}
 						current += 3
 					} else {
 						if current == 0 && !IsVowel(3) && (GetAt(3) != 'W') {
-							MetaphAdd("X", "S")
+							MetaphAddAlt("X", "S")
 						} else {
 							MetaphAdd("X")
 						}
@@ -641,7 +788,7 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 
 			//french e.g. 'resnais', 'artois'
 			if current == last && StringAt((current-2), 2, "AI", "OI") {
-				MetaphAdd("", "S")
+				MetaphAddAlt("", "S")
 			} else {
 				MetaphAdd("S")
 			}
@@ -672,7 +819,7 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 					StringAt(0, 3, "SCH") {
 					MetaphAdd("T")
 				} else {
-					MetaphAdd("0", "T")
+					MetaphAddAlt("0", "T")
 				}
 				current += 2
 				break
@@ -703,7 +850,7 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 				(IsVowel(current+1) || StringAt(current, 2, "WH")) {
 				//Wasserman should match Vasserman
 				if IsVowel(current + 1) {
-					MetaphAdd("A", "F")
+					MetaphAddAlt("A", "F")
 				} else {
 					//need Uomo to match Womo
 					MetaphAdd("A")
@@ -714,14 +861,14 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 			if (current == last && IsVowel(current-1)) ||
 				StringAt((current-1), 5, "EWSKI", "EWSKY", "OWSKI", "OWSKY") ||
 				StringAt(0, 3, "SCH") {
-				MetaphAdd("", "F")
+				MetaphAddAlt("", "F")
 				current += 1
 				break
 			}
 
 			//polish e.g. 'filipowicz'
 			if StringAt(current, 4, "WICZ", "WITZ") {
-				MetaphAdd("TS", "FX")
+				MetaphAddAlt("TS", "FX")
 				current += 4
 				break
 			}
@@ -750,7 +897,7 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 			} else {
 				if StringAt((current+1), 2, "ZO", "ZI", "ZA") ||
 					(SlavoGermanic() && ((current > 0) && GetAt(current-1) != 'T')) {
-					MetaphAdd("S", "TS")
+					MetaphAddAlt("S", "TS")
 				} else {
 					MetaphAdd("S")
 				}
@@ -764,19 +911,13 @@ func DoubleMetaphone(word string, maxlength int) (metaph, metaph2 string) {
 		default:
 			current += 1
 		}
+		Fire(start, "", p, s)
 	}
 
-	metaph = primary.String()
-	if len(metaph) > maxlength {
-		metaph = metaph[:maxlength]
-	}
-
+	// Write has kept both codes to maxlength characters, and the loop
+	// has stopped as soon as both were complete.
+	res.Metaph = primary
 	if alternate {
-		metaph2 = secondary.String()
-		if len(metaph2) > maxlength {
-			metaph2 = metaph2[:maxlength]
-		}
+		res.Metaph2 = secondary
 	}
-
-	return
 }
@@ -85,3 +85,28 @@ func TestConvenience(t *testing.T) {
 		t.Errorf("got: %d;  want: 11", len(words))
 	}
 }
+
+func BenchmarkDoubleMetaphone(b *testing.B) {
+	words, err := readFileLines("testInputData.txt.gz")
+	if err != nil {
+		b.Fatalf("%v", err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DoubleMetaphone(words[i%len(words)], 6)
+	}
+}
+
+func TestEarlyTermination(t *testing.T) {
+	var fired []string
+	enc := &Encoder{MaxLen: 2, Hooks: &Hooks{OnRule: func(pos int, rule, m,
+		m2 string) {
+		fired = append(fired, fmt.Sprintf("%d:%s=%s/%s", pos, rule, m, m2))
+	}}}
+	m, m2 := enc.Encode("Schwarzenegger")
+	want := "[0:SCH=X/X 3:W=/F 4:A=/ 5:R=R/]"
+	if got := fmt.Sprint(fired); m != "XR" || m2 != "XF" || got != want {
+		t.Errorf("got: %s, %s %s;  want: XR, XF %s", m, m2, got, want)
+	}
+}
@@ -0,0 +1,108 @@
+// metrics.go - count encoding and matching for Prometheus.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+)
+
+// matchBuckets are the upper bounds of the match count histogram.
+var matchBuckets = []int{0, 1, 2, 5, 10, 20, 50, 100}
+
+// Metrics counts the work of the Encoders and MetaphMaps whose Hooks it
+// provides, and reports the counts in the Prometheus text exposition
+// format.  It is an http.Handler, so a server can expose it on /metrics
+// without depending on a Prometheus client library:
+//
+//	m := metaphone.NewMetrics()
+//	opts := &metaphone.Options{Encoder: &metaphone.Encoder{Hooks: m.Hooks()}}
+//	// ... make MetaphMaps with opts ...
+//	http.Handle("/metrics", m)
+//
+// A Metrics is safe for concurrent use.
+type Metrics struct {
+	encodes, matches     atomic.Int64
+	cacheHits, cacheMiss atomic.Int64
+	matchSum             atomic.Int64
+	matchCounts          []atomic.Int64 // by matchBuckets index, not cumulative
+}
+
+// NewMetrics returns a Metrics with all counts zero.
+func NewMetrics() *Metrics {
+	return &Metrics{matchCounts: make([]atomic.Int64, len(matchBuckets)+1)}
+}
+
+// Hooks returns Hooks that update m.
+func (m *Metrics) Hooks() *Hooks {
+	return &Hooks{
+		OnEncode: func(word, metaph, metaph2 string) { m.encodes.Add(1) },
+		OnMatch: func(word string, matches []string) {
+			m.matches.Add(1)
+			m.matchSum.Add(int64(len(matches)))
+			i := 0
+			for i < len(matchBuckets) && len(matches) > matchBuckets[i] {
+				i++
+			}
+			m.matchCounts[i].Add(1)
+		},
+		OnCacheHit:  func(key string) { m.cacheHits.Add(1) },
+		OnCacheMiss: func(key string) { m.cacheMiss.Add(1) },
+	}
+}
+
+// WriteTo writes m's counts to w in the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (n int64, err error) {
+	cw := &countWriter{w: w}
+	counter := func(name, help string, v int64) {
+		fmt.Fprintf(cw, "# HELP %s %s\n# TYPE %s counter\n%s %d\n",
+			name, help, name, name, v)
+	}
+	counter("metaphone_encodes_total", "Words encoded.", m.encodes.Load())
+	counter("metaphone_matches_total", "Words looked up with MatchWord.",
+		m.matches.Load())
+	counter("metaphone_cache_hits_total", "Lookups answered from a cache.",
+		m.cacheHits.Load())
+	counter("metaphone_cache_misses_total", "Lookups a cache could not answer.",
+		m.cacheMiss.Load())
+	const name = "metaphone_match_results"
+	fmt.Fprintf(cw, "# HELP %s Words returned per MatchWord lookup.\n", name)
+	fmt.Fprintf(cw, "# TYPE %s histogram\n", name)
+	var cum int64
+	for i, le := range matchBuckets {
+		cum += m.matchCounts[i].Load()
+		fmt.Fprintf(cw, "%s_bucket{le=\"%d\"} %d\n", name, le, cum)
+	}
+	cum += m.matchCounts[len(matchBuckets)].Load()
+	fmt.Fprintf(cw, "%s_bucket{le=\"+Inf\"} %d\n", name, cum)
+	fmt.Fprintf(cw, "%s_sum %d\n%s_count %d\n", name, m.matchSum.Load(),
+		name, cum)
+	return cw.n, cw.err
+}
+
+// ServeHTTP writes m's counts as WriteTo does.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	m.WriteTo(w)
+}
+
+// countWriter counts the bytes written to w and keeps the first error.
+type countWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+// Write writes p to cw.w unless an earlier write failed.
+func (cw *countWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	cw.err = err
+	return n, err
+}
@@ -0,0 +1,34 @@
+// metrics_test.go - test metrics.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	m := NewMetrics()
+	metaph := NewMetaphMapWithOptions([]string{"Smith", "Smyth", "Jones"}, 4,
+		&Options{Encoder: &Encoder{Hooks: m.Hooks()}})
+	metaph.MatchWord("Smithe")
+	metaph.MatchWord("Xyzzy")
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+	for _, want := range []string{
+		"metaphone_encodes_total 5\n",
+		"metaphone_matches_total 2\n",
+		"metaphone_match_results_bucket{le=\"0\"} 1\n",
+		"metaphone_match_results_bucket{le=\"1\"} 1\n",
+		"metaphone_match_results_bucket{le=\"2\"} 2\n",
+		"metaphone_match_results_sum 2\n",
+		"metaphone_match_results_count 2\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("missing %q in:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// align.go - align the tokens of two full names.
+// Created 2026-10-16 and placed in the public domain.
+
+package namematch
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charltoncr/metaphone"
+)
+
+// Pair is a token of one name aligned with a token of another.  A token
+// with no counterpart has an empty partner and Kind "unmatched".
+type Pair struct {
+	A, B  string
+	Score float64
+	// Kind is "exact", "strong", "normal", "weak", "initial" or
+	// "unmatched".
+	Kind string
+}
+
+// Alignment explains how the tokens of two names were matched.
+type Alignment struct {
+	// Score is the mean score of the aligned pairs over the tokens of the
+	// shorter name, from 0 to 1.  Extra tokens of the longer name, such
+	// as a middle name missing from the other name, do not lower it.
+	Score float64
+	// Pairs holds the aligned pairs in the token order of the first name,
+	// followed by any unmatched tokens of the second name.
+	Pairs []Pair
+}
+
+// String returns a one-line explanation of al, such as
+// "0.95: John=JON (exact), Q=- (unmatched), Smith=Smyth (strong)".
+func (al Alignment) String() string {
+	parts := make([]string, len(al.Pairs))
+	for i, p := range al.Pairs {
+		a, b := p.A, p.B
+		if len(a) == 0 {
+			a = "-"
+		}
+		if len(b) == 0 {
+			b = "-"
+		}
+		parts[i] = fmt.Sprintf("%s=%s (%s)", a, b, p.Kind)
+	}
+	return fmt.Sprintf("%.2f: %s", al.Score, strings.Join(parts, ", "))
+}
+
+// Align matches the tokens of full names a and b regardless of their
+// order, so "Smith, John" aligns with "John Smith".  Titles, suffixes and
+// punctuation are ignored, initials match tokens starting with the same
+// letter, and tokens left over, such as a missing middle name, are
+// reported as unmatched.  Tokens are paired greedily, best score first.
+func (s *Scorer) Align(a, b string) (al Alignment) {
+	ta, tb := tokens(a), tokens(b)
+	type cand struct {
+		i, j  int
+		score float64
+		kind  string
+	}
+	var cands []cand
+	for i, x := range ta {
+		for j, y := range tb {
+			if score, kind := s.tokenMatch(x, y); score > 0 {
+				cands = append(cands, cand{i, j, score, kind})
+			}
+		}
+	}
+	// Best score first; prefer tokens in the same position on ties.
+	sort.SliceStable(cands, func(x, y int) bool {
+		if cands[x].score != cands[y].score {
+			return cands[x].score > cands[y].score
+		}
+		return abs(cands[x].i-cands[x].j) < abs(cands[y].i-cands[y].j)
+	})
+	matchA := make([]int, len(ta))
+	for i := range matchA {
+		matchA[i] = -1
+	}
+	usedB := make([]bool, len(tb))
+	kinds := make([]string, len(ta))
+	scores := make([]float64, len(ta))
+	for _, c := range cands {
+		if matchA[c.i] < 0 && !usedB[c.j] {
+			matchA[c.i], usedB[c.j] = c.j, true
+			kinds[c.i], scores[c.i] = c.kind, c.score
+		}
+	}
+	var sum float64
+	for i, x := range ta {
+		if matchA[i] < 0 {
+			al.Pairs = append(al.Pairs, Pair{A: x, Kind: "unmatched"})
+			continue
+		}
+		sum += scores[i]
+		al.Pairs = append(al.Pairs,
+			Pair{A: x, B: tb[matchA[i]], Score: scores[i], Kind: kinds[i]})
+	}
+	for j, y := range tb {
+		if !usedB[j] {
+			al.Pairs = append(al.Pairs, Pair{B: y, Kind: "unmatched"})
+		}
+	}
+	if n := min(len(ta), len(tb)); n > 0 {
+		al.Score = sum / float64(n)
+	}
+	return
+}
+
+// tokens returns the words of name without titles, suffixes, periods and
+// commas.
+func tokens(name string) []string {
+	name = metaphone.StripHonorifics(name)
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return r == '.' || r == ',' || unicode.IsSpace(r)
+	})
+}
+
+// abs returns the absolute value of x.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,179 @@
+// namematch.go - score how well two person names match.
+// Created 2026-10-16 and placed in the public domain.
+
+// Package namematch scores how well two person names match by comparing
+// the DoubleMetaphone codes of their given, middle and family names.
+// It handles the parsing and per-field bookkeeping that record linkage
+// of person names otherwise requires of each caller.  Typical use:
+//
+//	import "github.com/charltoncr/metaphone/namematch"
+//	// ...
+//	s := namematch.NewScorer(4)
+//	r := s.Score("Jon A. Smyth", "Smith, John")
+//	// r.Total is near 1 for likely matches and near 0 for unlikely ones.
+package namematch
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charltoncr/metaphone"
+)
+
+// Name holds the parts of a person's name.  Middle holds all names
+// between the given and family names, separated by spaces.
+type Name struct {
+	Given, Middle, Family string
+}
+
+// Parse splits name into given, middle and family names.  Both
+// "Given Middle Family" and "Family, Given Middle" forms are understood.
+// A name of a single word is taken to be a family name.  Titles and
+// suffixes are removed with metaphone.StripHonorifics, and periods are
+// removed, so "J." is returned as the initial "J".
+func Parse(name string) Name {
+	return parse(strings.ReplaceAll(metaphone.StripHonorifics(name), ".", " "))
+}
+
+// parse is Parse without removal of titles, suffixes and periods.
+func parse(name string) (n Name) {
+	if family, rest, found := strings.Cut(name, ","); found {
+		n = parse(rest)
+		n.Given, n.Middle = joinParts(n.Given, n.Middle, n.Family)
+		n.Family = strings.Join(strings.Fields(family), " ")
+		return
+	}
+	f := strings.Fields(name)
+	switch len(f) {
+	case 0:
+	case 1:
+		n.Family = f[0]
+	default:
+		n.Given = f[0]
+		n.Middle = strings.Join(f[1:len(f)-1], " ")
+		n.Family = f[len(f)-1]
+	}
+	return
+}
+
+// joinParts returns the first non-empty part of parts and the remaining
+// non-empty parts joined by spaces.
+func joinParts(parts ...string) (first, rest string) {
+	var f []string
+	for _, p := range parts {
+		if len(p) > 0 {
+			f = append(f, p)
+		}
+	}
+	if len(f) > 0 {
+		first = f[0]
+		rest = strings.Join(f[1:], " ")
+	}
+	return
+}
+
+// Weights holds the relative weight of each name field in a composite
+// score.  Weights need not sum to 1.
+type Weights struct {
+	Given, Middle, Family float64
+}
+
+// DefaultWeights are the Weights used by NewScorer.  The family name
+// counts most and the middle name least.
+var DefaultWeights = Weights{Given: 0.35, Middle: 0.15, Family: 0.5}
+
+// Scores given to token pairs by Scorer.  A token is a single word of a
+// name.
+const (
+	ExactScore   = 1.0 // tokens are equal, ignoring case
+	StrongScore  = 0.9 // metaphone.Strong
+	NormalScore  = 0.8 // metaphone.Normal
+	WeakScore    = 0.7 // metaphone.Weak
+	InitialScore = 0.6 // an initial matches the first letter of a token
+)
+
+// Scorer scores person names against each other.
+type Scorer struct {
+	// Weights weighs the fields of a name in Result.Total.
+	Weights Weights
+	// MaxLen is the maximum length of the DoubleMetaphone codes compared.
+	MaxLen int
+}
+
+// NewScorer returns a Scorer with DefaultWeights that compares
+// DoubleMetaphone codes of at most maxLen characters.
+func NewScorer(maxLen int) *Scorer {
+	return &Scorer{Weights: DefaultWeights, MaxLen: maxLen}
+}
+
+// Result holds a composite match score and the score of each field.
+// Each score is from 0 (no match) to 1 (exact match).  A field that is
+// empty in either name has a score of 0 and does not count in Total.
+type Result struct {
+	Total                 float64
+	Given, Middle, Family float64
+}
+
+// Score parses names a and b and returns how well they match.
+func (s *Scorer) Score(a, b string) Result {
+	return s.ScoreNames(Parse(a), Parse(b))
+}
+
+// ScoreNames returns how well names a and b match.
+func (s *Scorer) ScoreNames(a, b Name) (r Result) {
+	var sum, weight float64
+	field := func(x, y string, w float64) float64 {
+		if len(x) == 0 || len(y) == 0 {
+			return 0
+		}
+		score := s.TokenScore(x, y)
+		sum += w * score
+		weight += w
+		return score
+	}
+	r.Given = field(a.Given, b.Given, s.Weights.Given)
+	r.Middle = field(a.Middle, b.Middle, s.Weights.Middle)
+	r.Family = field(a.Family, b.Family, s.Weights.Family)
+	if weight > 0 {
+		r.Total = sum / weight
+	}
+	return
+}
+
+// TokenScore returns how well name tokens a and b match, from 0 to 1.
+// See ExactScore and the other score constants.
+func (s *Scorer) TokenScore(a, b string) float64 {
+	score, _ := s.tokenMatch(a, b)
+	return score
+}
+
+// tokenMatch returns how well name tokens a and b match and the kind of
+// match: "exact", "strong", "normal", "weak", "initial" or "none".
+func (s *Scorer) tokenMatch(a, b string) (score float64, kind string) {
+	a, b = strings.ToUpper(a), strings.ToUpper(b)
+	if a == b {
+		return ExactScore, "exact"
+	}
+	if isInitial(a) || isInitial(b) {
+		ra, _ := utf8.DecodeRuneInString(a)
+		rb, _ := utf8.DecodeRuneInString(b)
+		if ra == rb {
+			return InitialScore, "initial"
+		}
+		return 0, "none"
+	}
+	switch metaphone.Compare(a, b, s.MaxLen) {
+	case metaphone.Strong:
+		return StrongScore, "strong"
+	case metaphone.Normal:
+		return NormalScore, "normal"
+	case metaphone.Weak:
+		return WeakScore, "weak"
+	}
+	return 0, "none"
+}
+
+// isInitial returns true if token is a single letter.
+func isInitial(token string) bool {
+	return utf8.RuneCountInString(token) == 1
+}
@@ -0,0 +1,53 @@
+// namematch_test.go - test namematch.go.
+// This file is public domain.
+
+package namematch
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Name
+	}{
+		{"John Smith", Name{"John", "", "Smith"}},
+		{"John Q. Public", Name{"John", "Q", "Public"}},
+		{"Smith, John Paul", Name{"John", "Paul", "Smith"}},
+		{"Mary Ann Lee Jones", Name{"Mary", "Ann Lee", "Jones"}},
+		{"Cher", Name{"", "", "Cher"}},
+		{"Dr. Jane Doe, Ph.D.", Name{"Jane", "", "Doe"}},
+		{"  ", Name{}},
+	}
+	for _, tt := range tests {
+		if got := Parse(tt.in); got != tt.want {
+			t.Errorf("Parse(%q) got: %+v;  want: %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestScore(t *testing.T) {
+	s := NewScorer(4)
+	good := s.Score("Jon A. Smyth", "Smith, John Alan")
+	if good.Total < 0.8 {
+		t.Errorf("got: %+v;  want Total >= 0.8", good)
+	}
+	if good.Middle != InitialScore {
+		t.Errorf("got Middle: %v;  want: %v", good.Middle, InitialScore)
+	}
+	bad := s.Score("John Smith", "Mary Jones")
+	if bad.Total != 0 {
+		t.Errorf("got: %+v;  want Total 0", bad)
+	}
+}
+
+func TestAlign(t *testing.T) {
+	s := NewScorer(4)
+	al := s.Align("John Q. Smyth", "Smith, John")
+	want := "0.95: John=John (exact), Q=- (unmatched), Smyth=Smith (strong)"
+	if got := al.String(); got != want {
+		t.Errorf("got: %s;  want: %s", got, want)
+	}
+	if al := s.Align("A. Smith", "Smith Jones"); al.Score != 0.5 {
+		t.Errorf("got: %s;  want score 0.50", al)
+	}
+}
@@ -0,0 +1,271 @@
+// watchlist.go - screen names against sanctions and fraud watchlists.
+// Created 2026-10-16 and placed in the public domain.
+
+package namematch
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charltoncr/metaphone"
+)
+
+// Entry is a name on a watchlist, such as a sanctions or fraud list, with
+// the aliases it is also known by.
+type Entry struct {
+	ID      string
+	Name    string
+	Aliases []string
+}
+
+// HitFlags tell how a screened name matched a watchlist name.
+type HitFlags uint
+
+const (
+	// HitExact means the names are equal, ignoring case, accents,
+	// titles and punctuation.
+	HitExact HitFlags = 1 << iota
+	// HitPhonetic means a token matched by sound, not spelling.
+	HitPhonetic
+	// HitInitial means a token matched an initial.
+	HitInitial
+	// HitAlias means an alias of the entry matched, not its name.
+	HitAlias
+	// HitReordered means tokens matched in another order, as in
+	// "Smith John" and "John Smith".
+	HitReordered
+	// HitPartial means a token of either name matched nothing, as a
+	// missing middle name does.
+	HitPartial
+)
+
+// hitFlagNames are the names of HitFlags, in bit order.
+var hitFlagNames = []string{"exact", "phonetic", "initial", "alias",
+	"reordered", "partial"}
+
+// String returns the names of the flags of f joined by "|", such as
+// "phonetic|alias", or "none".
+func (f HitFlags) String() string {
+	var names []string
+	for i, name := range hitFlagNames {
+		if f&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, "|")
+}
+
+// Hit is a watchlist entry that a screened name matched.
+type Hit struct {
+	Entry Entry
+	// Matched is the name or alias of Entry that matched.
+	Matched string
+	// Score is the Alignment's score, from 0 to 1.
+	Score     float64
+	Flags     HitFlags
+	Alignment Alignment
+}
+
+// DefaultThreshold is the Threshold of a Watchlist made by NewWatchlist.
+const DefaultThreshold = 0.8
+
+// Watchlist screens names against a list of names and aliases.  The
+// DoubleMetaphone codes of the tokens of each listed name are indexed, so
+// Screen aligns a name only with the listed names that share a code with
+// it, which keeps screening fast enough to run for each transaction of a
+// large list.  A Watchlist is safe for concurrent Screens once built.
+type Watchlist struct {
+	// Scorer aligns and scores names; its MaxLen is fixed by
+	// NewWatchlist, since the index is made with it.
+	Scorer *Scorer
+	// Threshold is the lowest Score of a Hit.
+	Threshold float64
+	entries   []Entry
+	names     []listName
+	index     map[string][]int // token code to indexes into names
+}
+
+// listName is a name or alias of a watchlist entry.
+type listName struct {
+	entry int
+	name  string // with accents folded
+	alias bool
+}
+
+// NewWatchlist returns an empty Watchlist that compares DoubleMetaphone
+// codes of at most maxLen characters and has DefaultThreshold.
+func NewWatchlist(maxLen int) *Watchlist {
+	return &Watchlist{
+		Scorer:    NewScorer(maxLen),
+		Threshold: DefaultThreshold,
+		index:     make(map[string][]int),
+	}
+}
+
+// LoadWatchlist returns a Watchlist, as NewWatchlist does, of the entries
+// of CSV file r: one entry per record, with an ID, a name and any number
+// of aliases, as in
+//
+//	SDN-1234,Osama bin Laden,Usama bin Ladin,Usama bin Muhammad bin Laden
+//
+// Empty aliases are ignored.  A record with fewer than two fields is an
+// error that wraps metaphone.ErrDictionaryFormat.
+func LoadWatchlist(r io.Reader, maxLen int) (w *Watchlist, err error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	var records [][]string
+	if records, err = cr.ReadAll(); err != nil {
+		err = fmt.Errorf("%w: trying to read watchlist: %v",
+			metaphone.ErrDictionaryFormat, err)
+		return
+	}
+	w = NewWatchlist(maxLen)
+	for i, rec := range records {
+		if len(rec) < 2 {
+			err = fmt.Errorf("%w: record %d of watchlist has no name",
+				metaphone.ErrDictionaryFormat, i+1)
+			return nil, err
+		}
+		e := Entry{ID: rec[0], Name: rec[1]}
+		for _, alias := range rec[2:] {
+			if len(strings.TrimSpace(alias)) > 0 {
+				e.Aliases = append(e.Aliases, alias)
+			}
+		}
+		w.Add(e)
+	}
+	return
+}
+
+// Add adds entries to w.
+func (w *Watchlist) Add(entries ...Entry) {
+	for _, e := range entries {
+		w.entries = append(w.entries, e)
+		w.addName(e.Name, false)
+		for _, alias := range e.Aliases {
+			w.addName(alias, true)
+		}
+	}
+}
+
+// addName indexes name, of the last entry of w.
+func (w *Watchlist) addName(name string, alias bool) {
+	n := listName{entry: len(w.entries) - 1,
+		name: metaphone.FoldAccents(name), alias: alias}
+	w.names = append(w.names, n)
+	for _, code := range w.codes(n.name) {
+		w.index[code] = append(w.index[code], len(w.names)-1)
+	}
+}
+
+// codes returns the DoubleMetaphone codes of the tokens of name, except
+// initials.
+func (w *Watchlist) codes(name string) (codes []string) {
+	for _, token := range tokens(name) {
+		if utf8.RuneCountInString(token) < 2 {
+			continue
+		}
+		m, m2 := metaphone.DoubleMetaphone(token, w.Scorer.MaxLen)
+		for _, code := range []string{m, m2} {
+			if len(code) > 0 {
+				codes = append(codes, code)
+			}
+		}
+	}
+	return
+}
+
+// Len returns the number of entries in w.
+func (w *Watchlist) Len() int {
+	return len(w.entries)
+}
+
+// Screen returns the entries of w that name matches with a Score of at
+// least w.Threshold, best first, with the best-matching name or alias of
+// each.  Accents are ignored, as are titles, suffixes and punctuation.
+func (w *Watchlist) Screen(name string) (hits []Hit) {
+	name = metaphone.FoldAccents(name)
+	seen := make(map[int]bool)
+	best := make(map[int]int) // entry to index into hits
+	for _, code := range w.codes(name) {
+		for _, i := range w.index[code] {
+			if seen[i] {
+				continue
+			}
+			seen[i] = true
+			n := w.names[i]
+			al := w.Scorer.Align(name, n.name)
+			if al.Score < w.Threshold {
+				continue
+			}
+			h := Hit{Entry: w.entries[n.entry], Matched: n.name,
+				Score: al.Score, Flags: hitFlags(al, tokens(n.name), n.alias),
+				Alignment: al}
+			if j, ok := best[n.entry]; !ok {
+				best[n.entry] = len(hits)
+				hits = append(hits, h)
+			} else if h.Score > hits[j].Score {
+				hits[j] = h
+			}
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Entry.ID < hits[j].Entry.ID
+	})
+	return
+}
+
+// hitFlags returns the HitFlags of alignment al of a name with a listed
+// name of tokens listed, which is an alias if alias is true.
+func hitFlags(al Alignment, listed []string, alias bool) (f HitFlags) {
+	if alias {
+		f |= HitAlias
+	}
+	exact := true
+	var order []string // matched tokens of listed, in the name's order
+	for _, p := range al.Pairs {
+		switch p.Kind {
+		case "exact":
+		case "unmatched":
+			f |= HitPartial
+		case "initial":
+			f |= HitInitial
+		default:
+			f |= HitPhonetic
+		}
+		exact = exact && p.Kind == "exact"
+		if len(p.A) > 0 && len(p.B) > 0 {
+			order = append(order, p.B)
+		}
+	}
+	if exact {
+		f |= HitExact
+	}
+	matched := make(map[string]int)
+	for _, b := range order {
+		matched[b]++
+	}
+	i := 0
+	for _, token := range listed {
+		if matched[token] == 0 {
+			continue
+		}
+		matched[token]--
+		if order[i] != token {
+			f |= HitReordered
+			break
+		}
+		i++
+	}
+	return
+}
@@ -0,0 +1,80 @@
+// watchlist_test.go - test watchlist.go.
+// This file is public domain.
+
+package namematch
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charltoncr/metaphone"
+)
+
+const testList = `SDN-1,Osama bin Laden,Usama bin Ladin
+SDN-2,John Smith,
+SDN-3,Muammar Gaddafi,Moammar Qadhafi
+`
+
+func TestScreen(t *testing.T) {
+	w, err := LoadWatchlist(strings.NewReader(testList), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Len() != 3 {
+		t.Errorf("Len got: %d;  want: 3", w.Len())
+	}
+	tests := []struct {
+		name, id string
+		flags    HitFlags
+	}{
+		{"Usama bin Ladin", "SDN-1", HitExact | HitAlias},
+		{"Usama Bin Laden", "SDN-1", HitPhonetic},
+		{"Smith, Jon", "SDN-2", HitPhonetic | HitReordered},
+		{"J. Smith", "SDN-2", HitInitial},
+		{"Mr. John Q. Smith", "SDN-2", HitPartial},
+		{"Moamar Kadafi", "SDN-3", HitPhonetic},
+	}
+	for _, tt := range tests {
+		hits := w.Screen(tt.name)
+		if len(hits) == 0 {
+			t.Errorf("%s got no hits;  want %s", tt.name, tt.id)
+			continue
+		}
+		if h := hits[0]; h.Entry.ID != tt.id || h.Flags&tt.flags != tt.flags {
+			t.Errorf("%s got: %s %v (%s);  want: %s with %v", tt.name,
+				h.Entry.ID, h.Flags, h.Alignment, tt.id, tt.flags)
+		}
+	}
+	if hits := w.Screen("Mary Jones"); len(hits) != 0 {
+		t.Errorf("Mary Jones got: %v", hits)
+	}
+	if hits := w.Screen("Osama bin Laden"); len(hits) != 1 || hits[0].Score != 1 {
+		t.Errorf("got %d hits;  want one for the entry, not its alias too",
+			len(hits))
+	}
+
+	_, err = LoadWatchlist(strings.NewReader("SDN-4\n"), 4)
+	if !errors.Is(err, metaphone.ErrDictionaryFormat) {
+		t.Errorf("got: %v;  want: %v", err, metaphone.ErrDictionaryFormat)
+	}
+	if got := (HitPhonetic | HitAlias).String(); got != "phonetic|alias" {
+		t.Errorf("String got: %s", got)
+	}
+}
+
+func BenchmarkScreen(b *testing.B) {
+	w := NewWatchlist(4)
+	for i := range 10000 {
+		var name []byte
+		for n := i + 26*26*26; n > 0; n /= 26 {
+			name = append(name, byte('a'+n%26))
+		}
+		w.Add(Entry{ID: string(name), Name: "Abu " + string(name)})
+	}
+	w.Add(Entry{ID: "x", Name: "Osama bin Laden"})
+	b.ResetTimer()
+	for range b.N {
+		w.Screen("Usama bin Ladin")
+	}
+}
@@ -0,0 +1,294 @@
+// normalize.go - normalize words before they are encoded.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// A Normalizer transforms a word before it is encoded.  See
+// Options.Normalizers.
+type Normalizer func(word string) string
+
+// PrefixMode tells how SurnamePrefixes treats surname particles.
+type PrefixMode int
+
+const (
+	// PrefixKeep leaves particles as they are.
+	PrefixKeep PrefixMode = iota
+	// PrefixFuse joins a particle to the name that follows it, so
+	// "Van Dyke" becomes "VanDyke" and "O'Brien" becomes "OBrien".
+	PrefixFuse
+	// PrefixCanonical fuses particles and spells Mc as Mac, so
+	// "McDonald", "Mc Donald" and "MacDonald" are treated identically.
+	PrefixCanonical
+	// PrefixStrip removes particles, so "Van Dyke" becomes "Dyke" and
+	// "MacDonald" becomes "Donald".
+	PrefixStrip
+)
+
+// surnameParticles are the particles recognized by SurnamePrefixes.
+var surnameParticles = []string{"MC", "MAC", "O'", "O’", "VAN", "VON", "DE"}
+
+// SurnamePrefixes returns a Normalizer that treats the surname particles
+// Mc, Mac, O', Van, Von and De according to mode.  A particle is
+// recognized when it is a separate word ("Van Dyke"), when it is followed
+// by a capital letter ("MacDonald", "DeLuca"), or when it is followed by
+// an apostrophe ("O'Brien").  Mc is always recognized at the start of a
+// word, so "MCDONALD" is handled too, but "Mackenzie" and "Devon" are
+// left alone.
+func SurnamePrefixes(mode PrefixMode) Normalizer {
+	return func(word string) string {
+		if mode == PrefixKeep {
+			return word
+		}
+		f := strings.Fields(word)
+		out := make([]string, 0, len(f))
+		for i := 0; i < len(f); i++ {
+			particle, name := splitParticle(f[i])
+			if len(particle) == 0 && isParticle(f[i]) && i+1 < len(f) {
+				particle, name = f[i], f[i+1]
+				i++
+			}
+			if len(particle) == 0 {
+				out = append(out, f[i])
+				continue
+			}
+			particle = strings.TrimRight(particle, "'’")
+			switch mode {
+			case PrefixStrip:
+				out = append(out, name)
+			case PrefixCanonical:
+				if strings.EqualFold(particle, "MC") {
+					particle = particle[:1] + "ac"
+				}
+				fallthrough
+			default:
+				out = append(out, particle+name)
+			}
+		}
+		return strings.Join(out, " ")
+	}
+}
+
+// isParticle returns true if word is a surname particle by itself.
+func isParticle(word string) bool {
+	for _, p := range surnameParticles {
+		if strings.EqualFold(word, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitParticle returns the particle that starts word and the rest of
+// word, or two empty strings if word does not start with a particle.
+func splitParticle(word string) (particle, name string) {
+	for _, p := range surnameParticles {
+		n := len(p)
+		if len(word) <= n || !strings.EqualFold(word[:n], p) {
+			continue
+		}
+		next, _ := utf8.DecodeRuneInString(word[n:])
+		if p == "MC" || strings.HasSuffix(p, "'") || strings.HasSuffix(p, "’") ||
+			unicode.IsUpper(next) && !unicode.IsUpper(rune(word[n-1])) {
+			return word[:n], word[n:]
+		}
+	}
+	return
+}
+
+// honorifics are the titles removed by StripHonorifics.
+var honorifics = map[string]bool{
+	"MR": true, "MRS": true, "MS": true, "MISS": true, "MX": true,
+	"DR": true, "PROF": true, "REV": true, "FR": true, "SIR": true,
+	"DAME": true, "LORD": true, "LADY": true, "HON": true, "CAPT": true,
+	"COL": true, "GEN": true, "LT": true, "SGT": true, "MAJ": true,
+}
+
+// nameSuffixes are the suffixes removed by StripHonorifics.
+var nameSuffixes = map[string]bool{
+	"JR": true, "SR": true, "II": true, "III": true, "IV": true,
+	"ESQ": true, "PHD": true, "MD": true, "DDS": true,
+}
+
+// StripHonorifics is a Normalizer that removes titles such as Dr., Mrs.
+// and Rev. from the start of a name, and suffixes such as Jr., III and
+// Esq. from its end.  Both "Dr. John Smith, Jr." and "Smith Jr., Dr. John"
+// become names without title or suffix ("John Smith" and "Smith, John").
+func StripHonorifics(name string) string {
+	f := strings.Fields(name)
+	out := make([]string, 0, len(f))
+	groupStart := true
+	for i, tok := range f {
+		key := strings.ToUpper(strings.Trim(strings.ReplaceAll(tok, ".", ""), ","))
+		endsGroup := strings.HasSuffix(tok, ",")
+		switch {
+		case groupStart && honorifics[key] && i+1 < len(f):
+			continue
+		case len(out) > 0 && nameSuffixes[key] && (endsGroup || i+1 == len(f)):
+			if endsGroup && !strings.HasSuffix(out[len(out)-1], ",") {
+				out[len(out)-1] += ","
+			}
+			groupStart = true
+			continue
+		}
+		out = append(out, tok)
+		groupStart = endsGroup
+	}
+	if n := len(out); n > 0 {
+		out[n-1] = strings.TrimRight(out[n-1], ",")
+	}
+	return strings.Join(out, " ")
+}
+
+// singularExceptions are words ending in S that StripPlural leaves alone.
+var singularExceptions = map[string]bool{
+	"ALWAYS": true, "BIAS": true, "BUS": true, "CHAOS": true, "GAS": true,
+	"LENS": true, "NEWS": true, "PERHAPS": true, "SERIES": true,
+	"SPECIES": true, "THIS": true, "THUS": true, "YES": true,
+}
+
+// StripPlural is a Normalizer that removes possessive and plural endings
+// from each word of its argument, so "Johnson's", "Johnsons" and
+// "Johnsons'" all become "Johnson".  It handles 's, s', -ies (cities),
+// -es after s, x, z, ch and sh (boxes, churches) and a plain final s,
+// but leaves words of three or fewer letters, words ending in ss, us
+// or is, and a few common exceptions such as "news" alone.
+func StripPlural(word string) string {
+	f := strings.Fields(word)
+	for i, w := range f {
+		f[i] = singular(w)
+	}
+	return strings.Join(f, " ")
+}
+
+// singular returns w without a possessive or plural ending.
+func singular(w string) string {
+	for _, suffix := range []string{"'s", "’s", "'S", "’S"} {
+		if strings.HasSuffix(w, suffix) {
+			return w[:len(w)-len(suffix)]
+		}
+	}
+	w = strings.TrimRight(w, "'’")
+	u := strings.ToUpper(w)
+	switch {
+	case len(u) <= 3 || singularExceptions[u]:
+		return w
+	case strings.HasSuffix(u, "IES") && len(u) > 4:
+		if strings.HasSuffix(w, "ies") {
+			return w[:len(w)-3] + "y"
+		}
+		return w[:len(w)-3] + "Y"
+	case strings.HasSuffix(u, "SSES"), strings.HasSuffix(u, "XES"),
+		strings.HasSuffix(u, "ZES"), strings.HasSuffix(u, "CHES"),
+		strings.HasSuffix(u, "SHES"):
+		return w[:len(w)-2]
+	case strings.HasSuffix(u, "SS"), strings.HasSuffix(u, "US"),
+		strings.HasSuffix(u, "IS"):
+		return w
+	case strings.HasSuffix(u, "S"):
+		return w[:len(w)-1]
+	}
+	return w
+}
+
+// NormalizeUsername is a Normalizer for usernames and email addresses,
+// for finding duplicate accounts and impersonators.  It keeps only the
+// local part of an email address, drops a "+tag" suffix, splits the rest
+// into words at dots, underscores, hyphens, digits and camelCase
+// boundaries, drops the digits, and lower-cases the words, so
+// "John.Smith+shop@example.com", "jsmith_1987" and "JohnSmith" become
+// "john smith", "jsmith" and "john smith".
+func NormalizeUsername(name string) string {
+	if i := strings.LastIndexByte(name, '@'); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.IndexByte(name, '+'); i >= 0 {
+		name = name[:i]
+	}
+	var words []string
+	for _, w := range SplitIdentifier(name) {
+		if !unicode.IsDigit([]rune(w)[0]) {
+			words = append(words, strings.ToLower(w))
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// accentFold maps letters with diacritics to the letters they are folded
+// to by FoldAccents.
+var accentFold = func() map[rune]string {
+	m := make(map[rune]string)
+	for base, letters := range map[string]string{
+		"A": "ÀÁÂÃÄÅĀĂĄ", "a": "àáâãäåāăą",
+		"C": "ĆĈĊČ", "c": "ćĉċč",
+		"D": "ĎĐ", "d": "ďđ",
+		"E": "ÈÉÊËĒĔĖĘĚ", "e": "èéêëēĕėęě",
+		"G": "ĜĞĠĢ", "g": "ĝğġģ",
+		"H": "ĤĦ", "h": "ĥħ",
+		"I": "ÌÍÎÏĨĪĬĮİ", "i": "ìíîïĩīĭįı",
+		"J": "Ĵ", "j": "ĵ",
+		"K": "Ķ", "k": "ķ",
+		"L": "ĹĻĽĿŁ", "l": "ĺļľŀł",
+		"N": "ŃŅŇ", "n": "ńņň",
+		"O": "ÒÓÔÕÖØŌŎŐ", "o": "òóôõöøōŏő",
+		"R": "ŔŖŘ", "r": "ŕŗř",
+		"S": "ŚŜŞŠ", "s": "śŝşš",
+		"T": "ŢŤŦ", "t": "ţťŧ",
+		"U": "ÙÚÛÜŨŪŬŮŰŲ", "u": "ùúûüũūŭůűų",
+		"W": "Ŵ", "w": "ŵ",
+		"Y": "ÝŶŸ", "y": "ýÿŷ",
+		"Z": "ŹŻŽ", "z": "źżž",
+		"AE": "Æ", "ae": "æ", "OE": "Œ", "oe": "œ", "TH": "Þ", "th": "þ",
+	} {
+		for _, r := range letters {
+			m[r] = base
+		}
+	}
+	return m
+}()
+
+// FoldAccents is a Normalizer that removes diacritics from Latin letters,
+// so "Ångström" and "Angstrom" or "Éclair" and "eclair" have the same
+// codes; without it, an accented letter at the start of a word is not
+// encoded as a vowel.  Decomposed text, such as "C" followed by a
+// combining cedilla, is composed first, and combining marks left over are
+// removed.  Ç and Ñ are kept, since DoubleMetaphone encodes them itself,
+// as S and N.
+func FoldAccents(word string) string {
+	folds := func(r rune) bool {
+		_, ok := accentFold[r]
+		return ok || unicode.Is(unicode.Mn, r)
+	}
+	if !strings.ContainsFunc(word, folds) {
+		return word
+	}
+	word = norm.NFC.String(word)
+	var b strings.Builder
+	for _, r := range word {
+		if base, ok := accentFold[r]; ok {
+			b.WriteString(base)
+		} else if !unicode.Is(unicode.Mn, r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// FoldCompatibility is a Normalizer that puts words in Unicode
+// Normalization Form KC, which replaces compatibility characters with the
+// characters they stand for, such as the ligature "ﬁ" with "fi", "ｆｏｏ"
+// in fullwidth letters with "foo" and a superscript "²" with "2", so text
+// from PDFs and East Asian input methods is encoded as plain text is.
+func FoldCompatibility(word string) string {
+	if isASCII(word) {
+		return word
+	}
+	return norm.NFKC.String(word)
+}
@@ -0,0 +1,188 @@
+// normalize_test.go - test normalize.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func TestSurnamePrefixes(t *testing.T) {
+	tests := []struct {
+		mode    PrefixMode
+		in, out string
+	}{
+		{PrefixKeep, "Van Dyke", "Van Dyke"},
+		{PrefixFuse, "Van Dyke", "VanDyke"},
+		{PrefixFuse, "O'Brien", "OBrien"},
+		{PrefixCanonical, "McDonald", "MacDonald"},
+		{PrefixCanonical, "Mc Donald", "MacDonald"},
+		{PrefixCanonical, "MCDONALD", "MacDONALD"},
+		{PrefixStrip, "Ludwig von Braun", "Ludwig Braun"},
+		{PrefixStrip, "DeLuca", "Luca"},
+		{PrefixStrip, "Mackenzie", "Mackenzie"},
+		{PrefixStrip, "Devon", "Devon"},
+	}
+	for _, tt := range tests {
+		if got := SurnamePrefixes(tt.mode)(tt.in); got != tt.out {
+			t.Errorf("mode %d, %q got: %q;  want: %q", tt.mode, tt.in, got, tt.out)
+		}
+	}
+
+	opts := &Options{Normalizers: []Normalizer{SurnamePrefixes(PrefixStrip)}}
+	metaph := NewMetaphMapWithOptions([]string{"Van Dyke"}, 4, opts)
+	if got := metaph.MatchWord("Dyke"); len(got) != 1 {
+		t.Errorf("got: %v;  want: [Van Dyke]", got)
+	}
+}
+
+func TestStripHonorifics(t *testing.T) {
+	tests := []struct{ in, out string }{
+		{"Dr. John Smith, Jr.", "John Smith"},
+		{"Rev. Dr. Martin Luther King Jr.", "Martin Luther King"},
+		{"Smith Jr., Dr. John", "Smith, John"},
+		{"Mrs. Jane Doe, Esq.", "Jane Doe"},
+		{"Henry VIII", "Henry VIII"},
+		{"Miss", "Miss"},
+	}
+	for _, tt := range tests {
+		if got := StripHonorifics(tt.in); got != tt.out {
+			t.Errorf("%q got: %q;  want: %q", tt.in, got, tt.out)
+		}
+	}
+}
+
+func TestStopWordsAndMinLen(t *testing.T) {
+	opts := &Options{StopWords: []string{"the", "AND"}, MinLen: 3}
+	words := []string{"The", "an", "a", "and", "Ann", "Thee"}
+	metaph := NewMetaphMapWithOptions(words, 4, opts)
+	if got := fmt.Sprint(metaph.Words()); got != "[Ann Thee]" {
+		t.Errorf("got: %s;  want: [Ann Thee]", got)
+	}
+	// Queries are not filtered.
+	if got := metaph.MatchWord("the"); len(got) != 1 {
+		t.Errorf("got: %v;  want: [Thee]", got)
+	}
+}
+
+func TestCasePolicy(t *testing.T) {
+	words := []string{"Apple", "apple", "APPLE", "Apple"}
+	tests := []struct {
+		policy CasePolicy
+		want   string
+	}{
+		{CaseOriginal, "[APPLE Apple apple]"},
+		{CaseLower, "[apple]"},
+		{CaseFold, "[Apple]"},
+	}
+	for _, tt := range tests {
+		metaph := NewMetaphMapWithOptions(words, 4, &Options{Case: tt.policy})
+		matches := metaph.MatchWord("apel")
+		sort.Strings(matches)
+		if got := fmt.Sprint(matches); got != tt.want {
+			t.Errorf("policy %d got: %s;  want: %s", tt.policy, got, tt.want)
+		}
+	}
+}
+
+func TestStripPlural(t *testing.T) {
+	tests := []struct{ in, out string }{
+		{"Johnson's", "Johnson"},
+		{"Johnsons", "Johnson"},
+		{"Johnsons'", "Johnson"},
+		{"CITIES", "CITY"},
+		{"boxes churches classes", "box church class"},
+		{"glass bonus tennis news gas", "glass bonus tennis news gas"},
+		{"cats", "cat"},
+		{"bus", "bus"},
+	}
+	for _, tt := range tests {
+		if got := StripPlural(tt.in); got != tt.out {
+			t.Errorf("%q got: %q;  want: %q", tt.in, got, tt.out)
+		}
+	}
+}
+
+func TestSpellNumerals(t *testing.T) {
+	tests := []struct{ in, out string }{
+		{"4 Privet Drive", "four Privet Drive"},
+		{"21st Century", "twenty first Century"},
+		{"2nd 3RD 12th 40th 100th", "second third twelfth fortieth one hundredth"},
+		{"1,000,005", "one million five"},
+		{"B2B 1st1", "B2B 1st1"},
+	}
+	for _, tt := range tests {
+		if got := SpellNumerals(tt.in); got != tt.out {
+			t.Errorf("%q got: %q;  want: %q", tt.in, got, tt.out)
+		}
+	}
+}
+
+func TestNormalizeUsername(t *testing.T) {
+	tests := []struct{ in, out string }{
+		{"John.Smith+shop@example.com", "john smith"},
+		{"jsmith_1987", "jsmith"},
+		{"JohnSmith", "john smith"},
+		{"j0hn-smyth", "j hn smyth"},
+		{"12345", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeUsername(tt.in); got != tt.out {
+			t.Errorf("%q got: %q;  want: %q", tt.in, got, tt.out)
+		}
+	}
+	metaph, _ := NewBuilder().Normalizer(NormalizeUsername).
+		Words("john.smith@example.com", "mary.jones@example.com").Build()
+	if got := fmt.Sprint(metaph.MatchWord("Jon_Smyth77")); got != "[john.smith@example.com]" {
+		t.Errorf("got: %s;  want: [john.smith@example.com]", got)
+	}
+}
+
+func TestFoldAccents(t *testing.T) {
+	tests := []struct{ in, out string }{
+		{"Ångström", "Angstrom"},
+		{"Éclair", "Eclair"},
+		{"Café", "Cafe"},
+		{"Łódź", "Lodz"},
+		{"Æsop", "AEsop"},
+		{"Façade Niño", "Façade Niño"},
+		{"Fac\u0327ade Nin\u0303o", "Façade Niño"},
+		{"E\u0301clair", "Eclair"},
+		{"plain", "plain"},
+	}
+	for _, tt := range tests {
+		if got := FoldAccents(tt.in); got != tt.out {
+			t.Errorf("%q got: %q;  want: %q", tt.in, got, tt.out)
+		}
+	}
+	metaph, _ := NewBuilder().MaxLen(6).Normalizer(FoldAccents).
+		Words("Ångström", "eclair").Build()
+	for _, q := range []string{"Angstrom", "Ångström", "Éclair"} {
+		if got := metaph.MatchWord(q); len(got) != 1 {
+			t.Errorf("%s got: %v;  want one match", q, got)
+		}
+	}
+	if m, _ := metaph.Codes("Ångström"); m != "ANKSTR" {
+		t.Errorf("Codes got: %s;  want: ANKSTR", m)
+	}
+	if got := metaph.Normalize("Éclair"); got != "Eclair" {
+		t.Errorf("Normalize got: %s;  want: Eclair", got)
+	}
+}
+
+func TestFoldCompatibility(t *testing.T) {
+	tests := []struct{ in, out string }{
+		{"ﬁsh", "fish"},
+		{"ｆｏｏ", "foo"},
+		{"x²", "x2"},
+		{"Nin\u0303o", "Niño"},
+		{"plain", "plain"},
+	}
+	for _, tt := range tests {
+		if got := FoldCompatibility(tt.in); got != tt.out {
+			t.Errorf("%q got: %q;  want: %q", tt.in, got, tt.out)
+		}
+	}
+}
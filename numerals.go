@@ -0,0 +1,110 @@
+// numerals.go - spell out numerals as words.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"strconv"
+	"strings"
+)
+
+var smallNumbers = []string{"zero", "one", "two", "three", "four", "five",
+	"six", "seven", "eight", "nine", "ten", "eleven", "twelve", "thirteen",
+	"fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+
+var tensNumbers = []string{"", "", "twenty", "thirty", "forty", "fifty",
+	"sixty", "seventy", "eighty", "ninety"}
+
+var bigNumbers = []struct {
+	value uint64
+	name  string
+}{
+	{1e18, "quintillion"}, {1e15, "quadrillion"}, {1e12, "trillion"},
+	{1e9, "billion"}, {1e6, "million"}, {1e3, "thousand"}, {100, "hundred"},
+}
+
+// irregularOrdinals maps number words to ordinals not formed with -th.
+var irregularOrdinals = map[string]string{
+	"one": "first", "two": "second", "three": "third", "five": "fifth",
+	"eight": "eighth", "nine": "ninth", "twelve": "twelfth",
+}
+
+// SpellNumerals is a Normalizer that spells out each word of its argument
+// that is a numeral or an ordinal numeral, so "4" becomes "four", "21"
+// becomes "twenty one", "1,000" becomes "one thousand" and "2nd" becomes
+// "second".  Other words, such as "B2B", are left alone.
+func SpellNumerals(word string) string {
+	f := strings.Fields(word)
+	for i, w := range f {
+		if s, ok := spellNumeral(w); ok {
+			f[i] = s
+		}
+	}
+	return strings.Join(f, " ")
+}
+
+// spellNumeral returns numeral w spelled out and true, or "" and false if
+// w is not a numeral or an ordinal numeral.
+func spellNumeral(w string) (string, bool) {
+	digits := w
+	ordinal := false
+	if n := len(w); n > 2 {
+		switch strings.ToLower(w[n-2:]) {
+		case "st", "nd", "rd", "th":
+			digits, ordinal = w[:n-2], true
+		}
+	}
+	digits = strings.ReplaceAll(digits, ",", "")
+	if len(digits) == 0 || strings.Trim(digits, "0123456789") != "" {
+		return "", false
+	}
+	n, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		return "", false
+	}
+	s := numberWords(n)
+	if ordinal {
+		s = ordinalWords(s)
+	}
+	return s, true
+}
+
+// numberWords returns n spelled out in words separated by spaces, such as
+// "one thousand two hundred thirty four".
+func numberWords(n uint64) string {
+	if n < 20 {
+		return smallNumbers[n]
+	}
+	if n < 100 {
+		if n%10 == 0 {
+			return tensNumbers[n/10]
+		}
+		return tensNumbers[n/10] + " " + smallNumbers[n%10]
+	}
+	for _, big := range bigNumbers {
+		if n >= big.value {
+			s := numberWords(n/big.value) + " " + big.name
+			if rest := n % big.value; rest > 0 {
+				s += " " + numberWords(rest)
+			}
+			return s
+		}
+	}
+	return "" // not reached
+}
+
+// ordinalWords returns the ordinal form of number words s, such as
+// "twenty first" for "twenty one".
+func ordinalWords(s string) string {
+	i := strings.LastIndex(s, " ") + 1
+	last := s[i:]
+	switch {
+	case irregularOrdinals[last] != "":
+		last = irregularOrdinals[last]
+	case strings.HasSuffix(last, "y"):
+		last = last[:len(last)-1] + "ieth"
+	default:
+		last += "th"
+	}
+	return s[:i] + last
+}
@@ -0,0 +1,134 @@
+// phrase.go - encode multi-word phrases word by word.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"bufio"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Codes holds a word and its DoubleMetaphone codes.
+type Codes struct {
+	Word            string
+	Metaph, Metaph2 string
+}
+
+// Phrase holds the codes of each word of a phrase and the codes of the
+// whole phrase.  Metaph joins the primary codes of Words.  Metaph2 joins
+// their secondary codes, using a word's primary code where it has no
+// secondary code; Metaph2 is empty if no word has a secondary code.
+type Phrase struct {
+	Words           []Codes
+	Metaph, Metaph2 string
+}
+
+// EncodePhrase is like (*Encoder).EncodePhrase for an Encoder with a
+// MaxLen of 4.
+func EncodePhrase(phrase string) Phrase {
+	return (&Encoder{}).EncodePhrase(phrase)
+}
+
+// EncodePhrase splits phrase into words with enc.Split and encodes each
+// word separately, so that rules that look at spaces, such as
+// those for "VAN " and "VON " and for the last letter of a word, see each
+// word alone.  Each word's codes are limited to enc.MaxLen characters; the
+// joined codes of the phrase are not limited.  The codes are joined with
+// enc.Boundary between them.
+func (enc *Encoder) EncodePhrase(phrase string) (p Phrase) {
+	for _, word := range enc.words(phrase) {
+		m, m2 := enc.Encode(word)
+		p.Words = append(p.Words, Codes{Word: word, Metaph: m, Metaph2: m2})
+	}
+	p.Metaph, p.Metaph2 = joinCodes(p.Words, enc.boundary())
+	return
+}
+
+// joinCodes joins the codes of words as described for Phrase, with sep
+// between nonempty codes.
+func joinCodes(words []Codes, sep string) (metaph, metaph2 string) {
+	var primary, secondary strings.Builder
+	alternate := false
+	write := func(b *strings.Builder, code string) {
+		if b.Len() > 0 && len(code) > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(code)
+	}
+	for _, w := range words {
+		write(&primary, w.Metaph)
+		if len(w.Metaph2) > 0 {
+			alternate = true
+			write(&secondary, w.Metaph2)
+		} else {
+			write(&secondary, w.Metaph)
+		}
+	}
+	metaph = primary.String()
+	if alternate {
+		metaph2 = secondary.String()
+	}
+	return
+}
+
+// boundary returns enc.Boundary, or "" if enc is nil.
+func (enc *Encoder) boundary() string {
+	if enc == nil {
+		return ""
+	}
+	return enc.Boundary
+}
+
+// words returns the words of s as split by enc.Split.
+func (enc *Encoder) words(s string) (words []string) {
+	split := ScanWords
+	if enc != nil && enc.Split != nil {
+		split = enc.Split
+	}
+	sc := bufio.NewScanner(strings.NewReader(s))
+	sc.Buffer(nil, len(s)+1)
+	sc.Split(split)
+	for sc.Scan() {
+		words = append(words, sc.Text())
+	}
+	return
+}
+
+// ScanWords is a bufio.SplitFunc that returns each word of its input.
+// Words are runs of letters, digits and combining marks; everything else,
+// including whitespace, punctuation and invalid UTF-8, separates words.
+func ScanWords(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	start := 0
+	for start < len(data) {
+		if !atEOF && !utf8.FullRune(data[start:]) {
+			return start, nil, nil
+		}
+		r, width := utf8.DecodeRune(data[start:])
+		if isWordRune(r) {
+			break
+		}
+		start += width
+	}
+	for i := start; i < len(data); {
+		if !atEOF && !utf8.FullRune(data[i:]) {
+			return start, nil, nil
+		}
+		r, width := utf8.DecodeRune(data[i:])
+		if !isWordRune(r) {
+			return i + width, data[start:i], nil
+		}
+		i += width
+	}
+	if atEOF && len(data) > start {
+		return len(data), data[start:], nil
+	}
+	return start, nil, nil
+}
+
+// isWordRune returns true if r can be part of a word.
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError &&
+		(unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r))
+}
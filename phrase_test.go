@@ -0,0 +1,65 @@
+// phrase_test.go - test phrase.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestEncodePhrase(t *testing.T) {
+	p := EncodePhrase("Van Dyke, Schmidt")
+	want := []Codes{
+		{"Van", "FN", ""},
+		{"Dyke", "TK", ""},
+		{"Schmidt", "XMT", "SMT"},
+	}
+	if len(p.Words) != len(want) {
+		t.Fatalf("got: %+v;  want: %+v", p.Words, want)
+	}
+	for i := range want {
+		if p.Words[i] != want[i] {
+			t.Errorf("word %d got: %+v;  want: %+v", i, p.Words[i], want[i])
+		}
+	}
+	if p.Metaph != "FNTKXMT" || p.Metaph2 != "FNTKSMT" {
+		t.Errorf("got: %q %q;  want: \"FNTKXMT\" \"FNTKSMT\"", p.Metaph, p.Metaph2)
+	}
+	if p := EncodePhrase("Van Dyke"); p.Metaph2 != "" {
+		t.Errorf("got Metaph2: %q;  want: \"\"", p.Metaph2)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	var words []string
+	for _, c := range EncodePhrase("naïve,  café—ok ").Words {
+		words = append(words, c.Word)
+	}
+	if got, want := strings.Join(words, "|"), "naïve|café|ok"; got != want {
+		t.Errorf("got: %s;  want: %s", got, want)
+	}
+	enc := &Encoder{MaxLen: 8, Split: bufio.ScanWords}
+	p := enc.EncodePhrase("AB-1234 Mx")
+	if len(p.Words) != 2 || p.Words[0].Word != "AB-1234" {
+		t.Errorf("got: %+v;  want words AB-1234 and Mx", p.Words)
+	}
+}
+
+func TestBoundary(t *testing.T) {
+	enc := &Encoder{MaxLen: 8, Boundary: "|"}
+	if p := enc.EncodePhrase("ice land"); p.Metaph != "AS|LNT" {
+		t.Errorf("got: %s;  want: AS|LNT", p.Metaph)
+	}
+	if p := enc.EncodePhrase("Iceland"); p.Metaph != "ASLNT" {
+		t.Errorf("got: %s;  want: ASLNT", p.Metaph)
+	}
+	if p := (&Encoder{MaxLen: 8}).EncodePhrase("ice land"); p.Metaph != "ASLNT" {
+		t.Errorf("got: %s;  want: ASLNT", p.Metaph)
+	}
+	enc.Hyphens = PunctSplit
+	if m, m2 := enc.Encode("Smith-Jones"); m != "SM0|JNS" || m2 != "XMT|ANS" {
+		t.Errorf("got: %s, %s;  want: SM0|JNS, XMT|ANS", m, m2)
+	}
+}
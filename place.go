@@ -0,0 +1,42 @@
+// place.go - normalize place names for gazetteer lookups.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"strings"
+	"unicode"
+)
+
+// placeAbbreviations maps upper-cased abbreviations in place names to the
+// words they stand for.
+var placeAbbreviations = map[string]string{
+	"ST": "Saint", "STE": "Sainte", "MT": "Mount", "MTN": "Mountain",
+	"MTS": "Mountains", "FT": "Fort", "PT": "Point", "PTE": "Pointe",
+	"LK": "Lake", "SPG": "Spring", "SPGS": "Springs", "HTS": "Heights",
+	"JCT": "Junction", "VLY": "Valley", "CTR": "Center", "CY": "City",
+	"BCH": "Beach", "CK": "Creek", "CRK": "Creek", "FLS": "Falls",
+	"GRV": "Grove", "HBR": "Harbor", "IS": "Island", "ISL": "Island",
+	"PK": "Park", "SPRS": "Springs", "N": "North", "S": "South",
+	"E": "East", "W": "West", "NE": "Northeast", "NW": "Northwest",
+	"SE": "Southeast", "SW": "Southwest",
+}
+
+// NormalizePlace is a Normalizer for place names, for geocoding fallback
+// lookups.  It splits a name into words at spaces, periods, commas and
+// hyphens and expands common abbreviations, so "St. Louis", "Ft Worth",
+// "Mt.Vernon" and "N Platte" become "Saint Louis", "Fort Worth",
+// "Mount Vernon" and "North Platte".  The words are joined with spaces so
+// that each is encoded with the rules for a separate word.
+func NormalizePlace(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '.' || r == ',' || r == '-' ||
+			r == '‐'
+	})
+	for i, w := range words {
+		if full, ok := placeAbbreviations[strings.ToUpper(w)]; ok {
+			words[i] = full
+		}
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,37 @@
+// place_test.go - test place.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNormalizePlace(t *testing.T) {
+	tests := []struct{ in, out string }{
+		{"St. Louis", "Saint Louis"},
+		{"Ft Worth", "Fort Worth"},
+		{"Mt.Vernon", "Mount Vernon"},
+		{"N Platte", "North Platte"},
+		{"Winston-Salem", "Winston Salem"},
+		{"Sault Ste. Marie", "Sault Sainte Marie"},
+		{"Stanford", "Stanford"},
+	}
+	for _, tt := range tests {
+		if got := NormalizePlace(tt.in); got != tt.out {
+			t.Errorf("%q got: %q;  want: %q", tt.in, got, tt.out)
+		}
+	}
+	metaph, _ := NewBuilder().Normalizer(NormalizePlace).MaxLen(12).
+		Words("Saint Petersburg", "Fort Lauderdale", "Mount Pleasant").Build()
+	for query, want := range map[string]string{
+		"St Petersberg":  "[Saint Petersburg]",
+		"Ft. Lauderdail": "[Fort Lauderdale]",
+		"Mt. Pleasent":   "[Mount Pleasant]",
+	} {
+		if got := fmt.Sprint(metaph.MatchWord(query)); got != want {
+			t.Errorf("%s got: %s;  want: %s", query, got, want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// policy.go - decide whether two strings match by a configured policy.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import "strings"
+
+// MatchPolicy decides whether two strings match by thresholds set once,
+// such as "accept Strong and Normal matches, reject Weak ones", so that
+// code that compares strings in many places need not repeat the policy
+// at each of them.  The zero value accepts strings whose codes match in
+// any way.
+type MatchPolicy struct {
+	// Encoder encodes the strings compared.  A nil Encoder encodes like
+	// DoubleMetaphone with a maxlength of 4.
+	Encoder *Encoder
+	// MinStrength is the weakest Strength of match accepted.  It is Weak
+	// if NoMatch.
+	MinStrength Strength
+	// Calibrator, if not nil, also requires a match to have a confidence
+	// of at least MinConfidence.  Its weights are applied to the Evidence
+	// from Encoder; its own Encoder is not used.
+	Calibrator    *Calibrator
+	MinConfidence float64
+}
+
+// NewMatchPolicy returns a MatchPolicy that accepts matches of codes of
+// at most maxLen characters of minStrength or stronger.
+func NewMatchPolicy(maxLen int, minStrength Strength) *MatchPolicy {
+	return &MatchPolicy{Encoder: NewEncoder(maxLen), MinStrength: minStrength}
+}
+
+// Same returns true if p accepts a and b as a match.  Strings equal but
+// for case are always the same, even if they have no codes, as strings of
+// only digits or punctuation do.
+func (p *MatchPolicy) Same(a, b string) bool {
+	if strings.EqualFold(a, b) {
+		return true
+	}
+	e := p.Encoder.Evidence(a, b)
+	if e.Strength < max(p.MinStrength, Weak) {
+		return false
+	}
+	return p.Calibrator == nil || p.Calibrator.Calibrate(e) >= p.MinConfidence
+}
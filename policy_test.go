@@ -0,0 +1,34 @@
+// policy_test.go - test policy.go.
+// This file is public domain.
+
+package metaphone
+
+import "testing"
+
+func TestMatchPolicy(t *testing.T) {
+	strict := NewMatchPolicy(4, Normal)
+	calibrated := NewMatchPolicy(4, Weak)
+	calibrated.Calibrator = NewCalibrator(4)
+	calibrated.MinConfidence = 0.8
+	var zero MatchPolicy
+	tests := []struct {
+		p    *MatchPolicy
+		a, b string
+		want bool
+	}{
+		{strict, "Smith", "Smyth", true},
+		{strict, "Smith", "Jones", false},
+		{strict, "Jackson", "Jaxon", true},
+		{strict, "1234", "1234", true},
+		{strict, "1234", "4321", false},
+		{calibrated, "Jon", "John", true},
+		{calibrated, "Catherine", "Kathryn", false},
+		{&zero, "Catherine", "Kathryn", true},
+		{&zero, "Smith", "Jones", false},
+	}
+	for _, tt := range tests {
+		if got := tt.p.Same(tt.a, tt.b); got != tt.want {
+			t.Errorf("%q, %q: got: %v;  want: %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+// porter.go - the Porter stemming algorithm.
+// Created 2026-10-16 and placed in the public domain.
+//
+// Ported from Martin Porter's reference C implementation of the algorithm
+// described in "An algorithm for suffix stripping", Program 14(3), 1980,
+// including its two documented departures (-bli and -logi in step 2).
+
+package metaphone
+
+import "strings"
+
+// PorterStem is a Normalizer that reduces each word of its argument to
+// its stem with the Porter stemming algorithm, so inflected forms such as
+// "connected", "connecting" and "connections" all become "connect".
+// Stems are lower case.  Words with characters other than the letters
+// A-Z and a-z are left alone.
+func PorterStem(word string) string {
+	f := strings.Fields(word)
+	for i, w := range f {
+		f[i] = stem(w)
+	}
+	return strings.Join(f, " ")
+}
+
+// stem returns the Porter stem of w, or w if w is not all ASCII letters.
+func stem(w string) string {
+	b := []byte(strings.ToLower(w))
+	for _, c := range b {
+		if c < 'a' || c > 'z' {
+			return w
+		}
+	}
+	z := &stemmer{b: b, k: len(b) - 1}
+	if z.k > 1 {
+		z.step1ab()
+		if z.k > 0 {
+			z.step1c()
+			z.step2()
+			z.step3()
+			z.step4()
+			z.step5()
+		}
+	}
+	return string(z.b[:z.k+1])
+}
+
+// stemmer holds a word being stemmed in b[0:k+1].  j is a general offset
+// into b, set by ends.
+type stemmer struct {
+	b    []byte
+	k, j int
+}
+
+// cons returns true if b[i] is a consonant.
+func (z *stemmer) cons(i int) bool {
+	switch z.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !z.cons(i-1)
+	}
+	return true
+}
+
+// m returns the number of consonant sequences between 0 and j: with C a
+// consonant sequence and V a vowel sequence, and [] optional presence,
+// [C](VC){m}[V].
+func (z *stemmer) m() (n int) {
+	i := 0
+	for {
+		if i > z.j {
+			return
+		}
+		if !z.cons(i) {
+			break
+		}
+		i++
+	}
+	i++
+	for {
+		for {
+			if i > z.j {
+				return
+			}
+			if z.cons(i) {
+				break
+			}
+			i++
+		}
+		i++
+		n++
+		for {
+			if i > z.j {
+				return
+			}
+			if !z.cons(i) {
+				break
+			}
+			i++
+		}
+		i++
+	}
+}
+
+// vowelInStem returns true if b[0:j+1] contains a vowel.
+func (z *stemmer) vowelInStem() bool {
+	for i := 0; i <= z.j; i++ {
+		if !z.cons(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// doubleC returns true if b[j-1:j+1] is a double consonant.
+func (z *stemmer) doubleC(j int) bool {
+	return j >= 1 && z.b[j] == z.b[j-1] && z.cons(j)
+}
+
+// cvc returns true if b[i-2:i+1] is consonant-vowel-consonant and the
+// second consonant is not w, x or y.  It restores an e at the end of a
+// short word, e.g. cav(e), lov(e), hop(e), crim(e), but snow, box, tray.
+func (z *stemmer) cvc(i int) bool {
+	if i < 2 || !z.cons(i) || z.cons(i-1) || !z.cons(i-2) {
+		return false
+	}
+	c := z.b[i]
+	return c != 'w' && c != 'x' && c != 'y'
+}
+
+// ends returns true if b[0:k+1] ends with s, and then sets j to the
+// index before s.
+func (z *stemmer) ends(s string) bool {
+	n := len(s)
+	if n > z.k+1 || string(z.b[z.k-n+1:z.k+1]) != s {
+		return false
+	}
+	z.j = z.k - n
+	return true
+}
+
+// setTo replaces b[j+1:k+1] with s and adjusts k.
+func (z *stemmer) setTo(s string) {
+	z.b = append(z.b[:z.j+1], s...)
+	z.k = z.j + len(s)
+}
+
+// r replaces the suffix found by ends with s if m() > 0.
+func (z *stemmer) r(s string) {
+	if z.m() > 0 {
+		z.setTo(s)
+	}
+}
+
+// step1ab removes plurals and -ed or -ing, e.g.
+//
+//	caresses -> caress, ponies -> poni, cats -> cat, feed -> feed,
+//	agreed -> agree, plastered -> plaster, motoring -> motor,
+//	conflated -> conflate, hopping -> hop, filing -> file.
+func (z *stemmer) step1ab() {
+	if z.b[z.k] == 's' {
+		switch {
+		case z.ends("sses"):
+			z.k -= 2
+		case z.ends("ies"):
+			z.setTo("i")
+		case z.b[z.k-1] != 's':
+			z.k--
+		}
+	}
+	if z.ends("eed") {
+		if z.m() > 0 {
+			z.k--
+		}
+	} else if (z.ends("ed") || z.ends("ing")) && z.vowelInStem() {
+		z.k = z.j
+		switch {
+		case z.ends("at"):
+			z.setTo("ate")
+		case z.ends("bl"):
+			z.setTo("ble")
+		case z.ends("iz"):
+			z.setTo("ize")
+		case z.doubleC(z.k):
+			z.k--
+			if c := z.b[z.k]; c == 'l' || c == 's' || c == 'z' {
+				z.k++
+			}
+		case z.m() == 1 && z.cvc(z.k):
+			z.setTo("e")
+		}
+	}
+}
+
+// step1c turns a final y into i when there is another vowel in the stem.
+func (z *stemmer) step1c() {
+	if z.ends("y") && z.vowelInStem() {
+		z.b[z.k] = 'i'
+	}
+}
+
+// replaceFirst replaces the first of the suffixes in pairs (suffix,
+// replacement, ...) that ends b, as r does.
+func (z *stemmer) replaceFirst(pairs ...string) {
+	for i := 0; i < len(pairs); i += 2 {
+		if z.ends(pairs[i]) {
+			z.r(pairs[i+1])
+			return
+		}
+	}
+}
+
+// step2 maps double suffixes to single ones, so -ization (= -ize plus
+// -ation) maps to -ize, etc., when m() > 0.
+func (z *stemmer) step2() {
+	switch z.b[z.k-1] {
+	case 'a':
+		z.replaceFirst("ational", "ate", "tional", "tion")
+	case 'c':
+		z.replaceFirst("enci", "ence", "anci", "ance")
+	case 'e':
+		z.replaceFirst("izer", "ize")
+	case 'l':
+		z.replaceFirst("bli", "ble", "alli", "al", "entli", "ent", "eli", "e",
+			"ousli", "ous")
+	case 'o':
+		z.replaceFirst("ization", "ize", "ation", "ate", "ator", "ate")
+	case 's':
+		z.replaceFirst("alism", "al", "iveness", "ive", "fulness", "ful",
+			"ousness", "ous")
+	case 't':
+		z.replaceFirst("aliti", "al", "iviti", "ive", "biliti", "ble")
+	case 'g':
+		z.replaceFirst("logi", "log")
+	}
+}
+
+// step3 deals with -ic-, -full, -ness etc. similarly to step2.
+func (z *stemmer) step3() {
+	switch z.b[z.k] {
+	case 'e':
+		z.replaceFirst("icate", "ic", "ative", "", "alize", "al")
+	case 'i':
+		z.replaceFirst("iciti", "ic")
+	case 'l':
+		z.replaceFirst("ical", "ic", "ful", "")
+	case 's':
+		z.replaceFirst("ness", "")
+	}
+}
+
+// step4 takes off -ant, -ence etc. in context <c>vcvc<v>.
+func (z *stemmer) step4() {
+	var suffixes []string
+	switch z.b[z.k-1] {
+	case 'a':
+		suffixes = []string{"al"}
+	case 'c':
+		suffixes = []string{"ance", "ence"}
+	case 'e':
+		suffixes = []string{"er"}
+	case 'i':
+		suffixes = []string{"ic"}
+	case 'l':
+		suffixes = []string{"able", "ible"}
+	case 'n':
+		suffixes = []string{"ant", "ement", "ment", "ent"}
+	case 'o':
+		if z.ends("ion") && z.j >= 0 && (z.b[z.j] == 's' || z.b[z.j] == 't') {
+			break
+		}
+		suffixes = []string{"ou"}
+	case 's':
+		suffixes = []string{"ism"}
+	case 't':
+		suffixes = []string{"ate", "iti"}
+	case 'u':
+		suffixes = []string{"ous"}
+	case 'v':
+		suffixes = []string{"ive"}
+	case 'z':
+		suffixes = []string{"ize"}
+	default:
+		return
+	}
+	found := len(suffixes) == 0 // -ion after s or t
+	for _, s := range suffixes {
+		if z.ends(s) {
+			found = true
+			break
+		}
+	}
+	if found && z.m() > 1 {
+		z.k = z.j
+	}
+}
+
+// step5 removes a final -e if m() > 1, and changes -ll to -l if m() > 1.
+func (z *stemmer) step5() {
+	z.j = z.k
+	if z.b[z.k] == 'e' {
+		if a := z.m(); a > 1 || a == 1 && !z.cvc(z.k-1) {
+			z.k--
+		}
+	}
+	if z.b[z.k] == 'l' && z.doubleC(z.k) && z.m() > 1 {
+		z.k--
+	}
+}
@@ -0,0 +1,43 @@
+// porter_test.go - test porter.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPorterStem(t *testing.T) {
+	in := "caresses ponies ties caress cats feed agreed plastered bled " +
+		"motoring sing conflated troubled sized hopping tanned falling " +
+		"hissing fizzed failing filing happy sky relational conditional " +
+		"rational digitizer vietnamization predication operator " +
+		"feudalism decisiveness hopefulness callousness sensibiliti " +
+		"triplicate formative formalize electrical hopeful goodness " +
+		"revival allowance inference airliner gyroscopic adjustable " +
+		"defensible irritant replacement adjustment dependent adoption " +
+		"communism activate homologous effective bowdlerize probate " +
+		"rate cease controll roll generalizations oscillators " +
+		"Connections"
+	want := "caress poni ti caress cat feed agre plaster bled " +
+		"motor sing conflat troubl size hop tan fall " +
+		"hiss fizz fail file happi sky relat condit " +
+		"ration digit vietnam predic oper " +
+		"feudal decis hope callous sensibl " +
+		"triplic form formal electr hope good " +
+		"reviv allow infer airlin gyroscop adjust " +
+		"defens irrit replac adjust depend adopt " +
+		"commun activ homolog effect bowdler probat " +
+		"rate ceas control roll gener oscil " +
+		"connect"
+	got := strings.Fields(PorterStem(in))
+	for i, w := range strings.Fields(want) {
+		if got[i] != w {
+			t.Errorf("%s got: %s;  want: %s", strings.Fields(in)[i], got[i], w)
+		}
+	}
+	if got := PorterStem("café"); got != "café" {
+		t.Errorf("got: %s;  want: café", got)
+	}
+}
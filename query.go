@@ -0,0 +1,284 @@
+// query.go - more ways to query a MetaphMap.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Alliterations returns the words in metaph whose codes start with the
+// same n code symbols as a code of word, i.e. the words that start with
+// the same sound as word.  A code shorter than n symbols is used whole.
+// The words are sorted.  An n less than 1 finds no words.
+func (metaph *MetaphMap) Alliterations(word string, n int) (output []string) {
+	if metaph == nil || n < 1 {
+		return
+	}
+	m, m2 := metaph.encode(word)
+	for _, code := range []string{m, m2} {
+		if len(code) > n {
+			code = code[:n]
+		}
+		if len(code) > 0 {
+			output = append(output, metaph.MatchCodePrefix(code)...)
+		}
+	}
+	output = removeDups(output)
+	sort.Strings(output)
+	return
+}
+
+// MatchCodePrefix returns the words in metaph with a code that starts
+// with prefix, such as "NM" for "Naomi" and "Newman", sorted.  Search as
+// you type can show sound-alikes of a partial query from the codes of
+// what has been typed.  The codes of metaph are kept sorted, so a lookup
+// takes time logarithmic in their number plus that of the words found.
+func (metaph *MetaphMap) MatchCodePrefix(prefix string) (output []string) {
+	if metaph == nil {
+		return
+	}
+	codes := metaph.sortedCodes()
+	i, _ := slices.BinarySearch(codes, prefix)
+	for ; i < len(codes) && strings.HasPrefix(codes[i], prefix); i++ {
+		output = append(output, metaph.mapper[codes[i]]...)
+	}
+	output = removeDups(output)
+	sort.Strings(output)
+	return
+}
+
+// sortedCodes returns the codes of metaph, sorted, sorting them if they
+// changed since the last call.
+func (metaph *MetaphMap) sortedCodes() []string {
+	metaph.sortedMu.Lock()
+	defer metaph.sortedMu.Unlock()
+	if metaph.sorted == nil {
+		metaph.sorted = make([]string, 0, len(metaph.mapper))
+		for code := range metaph.mapper {
+			metaph.sorted = append(metaph.sorted, code)
+		}
+		slices.Sort(metaph.sorted)
+	}
+	return metaph.sorted
+}
+
+// Autocomplete returns at most n suggestions for prefix, the start of a
+// word typed in a search box: the words in metaph that start with prefix,
+// ignoring case, blended with the words that sound like they start with
+// it, whose codes start with a code of prefix, so "fone" suggests both
+// "fonetic" and "phone".  Suggestions are ranked by frequency, most
+// frequent first; of words equally frequent, those that start with prefix
+// come first, then the rest alphabetically.  An n less than 1 returns
+// all suggestions.
+func (metaph *MetaphMap) Autocomplete(prefix string, n int) (output []string) {
+	if metaph == nil || len(prefix) == 0 {
+		return
+	}
+	literal := make(map[string]bool)
+	lower := strings.ToLower(prefix)
+	words := metaph.sortedWordKeys()
+	i, _ := slices.BinarySearchFunc(words, lower,
+		func(k wordKey, s string) int { return strings.Compare(k.lower, s) })
+	for ; i < len(words) && strings.HasPrefix(words[i].lower, lower); i++ {
+		literal[words[i].word] = true
+		output = append(output, words[i].word)
+	}
+	m, m2 := metaph.encode(prefix)
+	for _, code := range []string{m, m2} {
+		if len(code) > 0 {
+			output = append(output, metaph.MatchCodePrefix(code)...)
+		}
+	}
+	output = removeDups(output)
+	sort.Slice(output, func(i, j int) bool {
+		wi, wj := output[i], output[j]
+		switch {
+		case metaph.freq[wi] != metaph.freq[wj]:
+			return metaph.freq[wi] > metaph.freq[wj]
+		case literal[wi] != literal[wj]:
+			return literal[wi]
+		}
+		return wi < wj
+	})
+	if n > 0 && len(output) > n {
+		output = output[:n]
+	}
+	return
+}
+
+// wordKey is a stored word and its lower-cased form.
+type wordKey struct {
+	lower, word string
+}
+
+// sortedWordKeys returns the words of metaph sorted by their lower-cased
+// form, sorting them if they changed since the last call.
+func (metaph *MetaphMap) sortedWordKeys() []wordKey {
+	metaph.sortedMu.Lock()
+	defer metaph.sortedMu.Unlock()
+	if metaph.sortedWords == nil {
+		metaph.sortedWords = make([]wordKey, 0, len(metaph.freq))
+		for w := range metaph.freq {
+			metaph.sortedWords = append(metaph.sortedWords,
+				wordKey{strings.ToLower(w), w})
+		}
+		slices.SortFunc(metaph.sortedWords, func(a, b wordKey) int {
+			if c := strings.Compare(a.lower, b.lower); c != 0 {
+				return c
+			}
+			return strings.Compare(a.word, b.word)
+		})
+	}
+	return metaph.sortedWords
+}
+
+// Nearest returns the word in metaph that best matches word, and true, or
+// "" and false if no word in metaph sounds like word.  Sound-alikes are
+// ranked by the Strength of their match with word, then by EditDistance
+// from word, then alphabetically.
+func (metaph *MetaphMap) Nearest(word string) (string, bool) {
+	if metaph == nil {
+		return "", false
+	}
+	matches := metaph.rank(word, metaph.MatchWord(word))
+	if len(matches) == 0 {
+		return "", false
+	}
+	return matches[0], true
+}
+
+// rank sorts candidates, best match with word first, as described for
+// Nearest, and returns them.
+func (metaph *MetaphMap) rank(word string, candidates []string) []string {
+	type scored struct {
+		word     string
+		strength Strength
+		dist     int
+	}
+	m, m2 := metaph.encode(word)
+	s := make([]scored, len(candidates))
+	for i, c := range candidates {
+		n, n2 := metaph.encode(c)
+		s[i] = scored{c, compareCodes(m, m2, n, n2), EditDistance(word, c)}
+	}
+	sort.Slice(s, func(i, j int) bool {
+		switch {
+		case s[i].strength != s[j].strength:
+			return s[i].strength > s[j].strength
+		case s[i].dist != s[j].dist:
+			return s[i].dist < s[j].dist
+		}
+		return s[i].word < s[j].word
+	})
+	for i := range s {
+		candidates[i] = s[i].word
+	}
+	return candidates
+}
+
+// ContainsSoundAlike returns true if a word in metaph sounds like word.
+// It is like len(metaph.MatchWord(word)) > 0 without making the list of
+// matches.
+func (metaph *MetaphMap) ContainsSoundAlike(word string) bool {
+	if metaph == nil {
+		return false
+	}
+	m, m2 := metaph.encode(word)
+	return len(m) > 0 && len(metaph.mapper[m]) > 0 ||
+		len(m2) > 0 && len(metaph.mapper[m2]) > 0
+}
+
+// MatchWordPage returns at most limit of the words in metaph that sound
+// like word, starting at offset in their sorted order, and the total
+// number of such words, so that a user interface can show a large set of
+// matches a page at a time.  A limit less than 1 returns all words from
+// offset on.
+func (metaph *MetaphMap) MatchWordPage(word string, offset, limit int) (
+	page []string, total int) {
+	matches := metaph.MatchWord(word)
+	sort.Strings(matches)
+	total = len(matches)
+	offset = min(max(offset, 0), total)
+	end := total
+	if limit > 0 {
+		end = min(offset+limit, total)
+	}
+	page = matches[offset:end]
+	return
+}
+
+// SortOrder tells how MatchWordSorted orders its results.  Ties are
+// broken alphabetically.
+type SortOrder int
+
+const (
+	// SortAlphabetical sorts words alphabetically.
+	SortAlphabetical SortOrder = iota
+	// SortLength sorts words by how much their length differs from the
+	// length of the word looked up, least first.
+	SortLength
+	// SortDistance sorts words by EditDistance from the word looked up,
+	// least first.
+	SortDistance
+	// SortFrequency sorts words by their frequency, most frequent first.
+	// A word's frequency is the number of times it appears in the word
+	// list, unless set by SetFrequency.
+	SortFrequency
+)
+
+// MatchWordSorted returns the words in metaph that sound like word, sorted
+// by order.
+func (metaph *MetaphMap) MatchWordSorted(word string, order SortOrder) []string {
+	matches := metaph.MatchWord(word)
+	n := utf8.RuneCountInString(word)
+	key := func(w string) int {
+		switch order {
+		case SortLength:
+			return abs(utf8.RuneCountInString(w) - n)
+		case SortDistance:
+			return EditDistance(word, w)
+		case SortFrequency:
+			return -metaph.freq[w]
+		}
+		return 0
+	}
+	keys := make(map[string]int, len(matches))
+	for _, w := range matches {
+		keys[w] = key(w)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		ki, kj := keys[matches[i]], keys[matches[j]]
+		if ki != kj {
+			return ki < kj
+		}
+		return matches[i] < matches[j]
+	})
+	return matches
+}
+
+// SetFrequency sets the frequency of word in metaph, as used by
+// SortFrequency, to n.  It does nothing if word is not in metaph; case is
+// ignored per metaph's CasePolicy.
+func (metaph *MetaphMap) SetFrequency(word string, n int) {
+	if metaph == nil {
+		return
+	}
+	if metaph.canon != nil {
+		word = metaph.canon[strings.ToLower(word)]
+	}
+	if _, ok := metaph.freq[word]; ok {
+		metaph.freq[word] = n
+	}
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
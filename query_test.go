@@ -0,0 +1,130 @@
+// query_test.go - test query.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAlliterations(t *testing.T) {
+	words := []string{"peter", "piper", "picked", "pickled", "peppers", "salt", "fish"}
+	metaph := NewMetaphMap(words, 4)
+	want := "[peppers peter picked pickled piper]"
+	if got := fmt.Sprint(metaph.Alliterations("Phillip", 1)); got == want {
+		t.Errorf("Phillip matched %s", got)
+	}
+	if got := fmt.Sprint(metaph.Alliterations("Paul", 1)); got != want {
+		t.Errorf("got: %s;  want: %s", got, want)
+	}
+	want = "[picked pickled]"
+	if got := fmt.Sprint(metaph.Alliterations("pick", 2)); got != want {
+		t.Errorf("got: %s;  want: %s", got, want)
+	}
+	for _, n := range []int{0, -1} {
+		if got := metaph.Alliterations("pick", n); got != nil {
+			t.Errorf("n %d got: %v;  want: []", n, got)
+		}
+	}
+}
+
+func TestNearest(t *testing.T) {
+	metaph := NewMetaphMap([]string{"Schmidt", "Smyth", "Smith", "Jones"}, 4)
+	if got, ok := metaph.Nearest("smith"); !ok || got != "Smith" {
+		t.Errorf("got: %q, %v;  want: \"Smith\", true", got, ok)
+	}
+	// Smoot's primary code, SMT, is Schmidt's secondary code.
+	if got, ok := metaph.Nearest("Smoot"); !ok || got != "Schmidt" {
+		t.Errorf("got: %q, %v;  want: \"Schmidt\", true", got, ok)
+	}
+	if got, ok := metaph.Nearest("Brown"); ok {
+		t.Errorf("got: %q, %v;  want: \"\", false", got, ok)
+	}
+}
+
+func TestContainsSoundAlike(t *testing.T) {
+	metaph := NewMetaphMap([]string{"admin", "root"}, 4)
+	if !metaph.ContainsSoundAlike("Addmyn") || metaph.ContainsSoundAlike("guest") {
+		t.Errorf("got wrong ContainsSoundAlike result")
+	}
+}
+
+func TestMatchWordPage(t *testing.T) {
+	metaph := NewMetaphMap([]string{"Smyth", "Smith", "Smithe", "Schmidt",
+		"Jones"}, 4)
+	tests := []struct {
+		offset, limit int
+		want          string
+	}{
+		{0, 2, "[Schmidt Smith] 4"},
+		{2, 2, "[Smithe Smyth] 4"},
+		{3, 0, "[Smyth] 4"},
+		{9, 2, "[] 4"},
+	}
+	for _, tt := range tests {
+		page, total := metaph.MatchWordPage("smith", tt.offset, tt.limit)
+		if got := fmt.Sprint(page, total); got != tt.want {
+			t.Errorf("%d, %d got: %s;  want: %s", tt.offset, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestMatchWordSorted(t *testing.T) {
+	metaph := NewMetaphMap([]string{"Smyth", "Smith", "Smithe", "Schmidt",
+		"Smith", "Smyth", "Smith"}, 4)
+	metaph.SetFrequency("Schmidt", 2)
+	tests := []struct {
+		order SortOrder
+		want  string
+	}{
+		{SortAlphabetical, "[Schmidt Smith Smithe Smyth]"},
+		{SortLength, "[Smithe Schmidt Smith Smyth]"},
+		{SortDistance, "[Smithe Smyth Smith Schmidt]"},
+		{SortFrequency, "[Smith Schmidt Smyth Smithe]"},
+	}
+	for _, tt := range tests {
+		got := fmt.Sprint(metaph.MatchWordSorted("Smythe", tt.order))
+		if got != tt.want {
+			t.Errorf("%d got: %s;  want: %s", tt.order, got, tt.want)
+		}
+	}
+}
+
+func TestMatchCodePrefix(t *testing.T) {
+	metaph := NewMetaphMap([]string{"Naomi", "Newman", "Norman", "Smith"}, 4)
+	if got := fmt.Sprint(metaph.MatchCodePrefix("NM")); got != "[Naomi Newman]" {
+		t.Errorf("got: %s;  want: [Naomi Newman]", got)
+	}
+	if got := metaph.MatchCodePrefix("Q"); len(got) != 0 {
+		t.Errorf("got: %v;  want: []", got)
+	}
+	metaph.add("Nemo")
+	if got := fmt.Sprint(metaph.MatchCodePrefix("NM")); got != "[Naomi Nemo Newman]" {
+		t.Errorf("after add got: %s;  want: [Naomi Nemo Newman]", got)
+	}
+	metaph.RemoveWhere(func(w string) bool { return w == "Naomi" })
+	if got := fmt.Sprint(metaph.MatchCodePrefix("N")); got != "[Nemo Newman Norman]" {
+		t.Errorf("after remove got: %s;  want: [Nemo Newman Norman]", got)
+	}
+}
+
+func TestAutocomplete(t *testing.T) {
+	metaph := NewMetaphMap([]string{"phone", "phonetic", "fond", "fone",
+		"Fonda", "photo", "Smith"}, 6)
+	metaph.SetFrequency("phone", 5)
+	want := "[phone Fonda fond fone phonetic]"
+	if got := fmt.Sprint(metaph.Autocomplete("fon", 0)); got != want {
+		t.Errorf("got: %s;  want: %s", got, want)
+	}
+	if got := fmt.Sprint(metaph.Autocomplete("fon", 2)); got != "[phone Fonda]" {
+		t.Errorf("got: %s;  want: [phone Fonda]", got)
+	}
+	if got := metaph.Autocomplete("", 5); len(got) != 0 {
+		t.Errorf("got: %v;  want: []", got)
+	}
+	metaph.add("Fonz")
+	if got := fmt.Sprint(metaph.Autocomplete("FONZ", 0)); got != "[Fonz]" {
+		t.Errorf("after add got: %s;  want: [Fonz]", got)
+	}
+}
@@ -0,0 +1,96 @@
+// record.go - score records of several fields against each other.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import "strings"
+
+// Field describes one field of the records a RecordMatcher scores, such
+// as a first name, last name or city.
+type Field struct {
+	// Name names the field, for the caller's use.
+	Name string
+	// Weight is the weight of the field in RecordScore.Total.  Weights
+	// need not sum to 1.
+	Weight float64
+	// Encoder encodes the field's values.  A nil Encoder encodes like
+	// DoubleMetaphone with a maxlength of 4.  A field of surnames might
+	// use longer codes than one of cities, or a field of Spanish given
+	// names AutoLanguage.
+	Encoder *Encoder
+}
+
+// Scores given to the values of a field by RecordMatcher.
+const (
+	FieldExact  = 1.0 // values are equal, ignoring case and extra spaces
+	FieldStrong = 0.9 // Strong
+	FieldNormal = 0.8 // Normal
+	FieldWeak   = 0.7 // Weak
+)
+
+// RecordMatcher scores records, each a slice of field values in the order
+// of its Fields, against each other, for identity resolution across
+// sources whose records spell names and places differently.
+type RecordMatcher struct {
+	Fields []Field
+}
+
+// NewRecordMatcher returns a RecordMatcher for records of fields.
+func NewRecordMatcher(fields ...Field) *RecordMatcher {
+	return &RecordMatcher{Fields: fields}
+}
+
+// RecordScore holds how well two records match: Total, the weighted mean
+// of the scores of their fields, and the score of each field.  Scores are
+// from 0 (no match) to 1 (exact match).  A field that is empty or missing
+// in either record has a score of 0 and does not count in Total, so
+// records that share no fields have a Total of 0.
+type RecordScore struct {
+	Total  float64
+	Fields []float64
+}
+
+// Score returns how well records a and b match.  Values of a and b
+// beyond rm's Fields are ignored.
+func (rm *RecordMatcher) Score(a, b []string) (r RecordScore) {
+	var sum, weight float64
+	r.Fields = make([]float64, len(rm.Fields))
+	for i, f := range rm.Fields {
+		if i >= len(a) || i >= len(b) {
+			break
+		}
+		x, y := strings.Fields(a[i]), strings.Fields(b[i])
+		if len(x) == 0 || len(y) == 0 {
+			continue
+		}
+		r.Fields[i] = f.score(strings.Join(x, " "), strings.Join(y, " "))
+		sum += f.Weight * r.Fields[i]
+		weight += f.Weight
+	}
+	if weight > 0 {
+		r.Total = sum / weight
+	}
+	return
+}
+
+// score returns the score of values a and b of f, which are not empty.
+// Values of several words are encoded as phrases.
+func (f Field) score(a, b string) float64 {
+	if strings.EqualFold(a, b) {
+		return FieldExact
+	}
+	enc := f.Encoder
+	if enc == nil {
+		enc = &Encoder{}
+	}
+	p, q := enc.EncodePhrase(a), enc.EncodePhrase(b)
+	switch compareCodes(p.Metaph, p.Metaph2, q.Metaph, q.Metaph2) {
+	case Strong:
+		return FieldStrong
+	case Normal:
+		return FieldNormal
+	case Weak:
+		return FieldWeak
+	}
+	return 0
+}
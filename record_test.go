@@ -0,0 +1,42 @@
+// record_test.go - test record.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRecordMatcher(t *testing.T) {
+	rm := NewRecordMatcher(
+		Field{Name: "first", Weight: 1},
+		Field{Name: "last", Weight: 2, Encoder: NewEncoder(6)},
+		Field{Name: "city", Weight: 1})
+	tests := []struct {
+		a, b   []string
+		total  float64
+		fields []float64
+	}{
+		{[]string{"John", "Smith", "New York"},
+			[]string{"john", "smith", " New  York "}, 1, []float64{1, 1, 1}},
+		{[]string{"Jon", "Smyth", "New York"},
+			[]string{"John", "Smith", "Boston"}, 0.675, []float64{0.9, 0.9, 0}},
+		{[]string{"John", "Smith", ""},
+			[]string{"John", "Jones", "Boston"}, 1.0 / 3, []float64{1, 0, 0}},
+		{[]string{"John"}, []string{"Jon", "Smith"}, 0.9, []float64{0.9, 0, 0}},
+		{nil, nil, 0, []float64{0, 0, 0}},
+	}
+	for _, tt := range tests {
+		r := rm.Score(tt.a, tt.b)
+		if math.Abs(r.Total-tt.total) > 1e-9 {
+			t.Errorf("%q, %q: got: %v;  want: %v", tt.a, tt.b, r.Total, tt.total)
+		}
+		for i, want := range tt.fields {
+			if math.Abs(r.Fields[i]-want) > 1e-9 {
+				t.Errorf("%q, %q: field %s got: %v;  want: %v",
+					tt.a, tt.b, rm.Fields[i].Name, r.Fields[i], want)
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// result.go - encode words into reusable buffers.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import "sync"
+
+// Result holds the primary and secondary codes of a word as bytes, for
+// EncodeInto.  Metaph2 is empty if the word has no secondary code.  The
+// zero value is ready to use.
+type Result struct {
+	Metaph, Metaph2 []byte
+}
+
+// results holds Results reused by DoubleMetaphone for its codes.
+var results = sync.Pool{New: func() any { return new(Result) }}
+
+// EncodeInto puts the codes that Encode returns for word in res, reusing
+// res's buffers, so that an indexing loop that calls EncodeInto with the
+// same Result for each word makes no garbage for the codes.  The codes in
+// res are overwritten by the next call with res.  Encoders that pad,
+// remap, split, spell out or detect the language of words, or that have
+// an OnEncode hook, encode with Encode and copy its codes into res.
+func (enc *Encoder) EncodeInto(word string, res *Result) {
+	if enc == nil {
+		enc = &Encoder{}
+	}
+	if enc.Version >= Version3 {
+		word = composeWord(word)
+	}
+	if enc.plain() {
+		res.doubleMetaphone(word, enc.MaxLen, enc)
+		return
+	}
+	m, m2 := enc.Encode(word)
+	res.Metaph = append(res.Metaph[:0], m...)
+	res.Metaph2 = append(res.Metaph2[:0], m2...)
+}
+
+// plain returns true if enc encodes words with DoubleMetaphone alone, as
+// Encode does with the zero Encoder but for MaxLen, Version, Rules,
+// Enhanced, Vowels and OnRule hooks.
+func (enc *Encoder) plain() bool {
+	return enc.Apostrophes == PunctKeep && enc.Hyphens == PunctKeep &&
+		enc.OtherPunct == PunctKeep && len(enc.Symbols) == 0 &&
+		enc.Width < 1 && !enc.Acronyms && !enc.AutoLanguage &&
+		!enc.DropTrailingS && !enc.Digits &&
+		(enc.Hooks == nil || enc.Hooks.OnEncode == nil)
+}
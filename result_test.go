@@ -0,0 +1,45 @@
+// result_test.go - test result.go.
+// This file is public domain.
+
+package metaphone
+
+import "testing"
+
+func TestEncodeInto(t *testing.T) {
+	encoders := []*Encoder{nil, {MaxLen: 6}, {MaxLen: 6, Hyphens: PunctSplit},
+		{MaxLen: 6, Width: 8}, {MaxLen: 8, Vowels: true}}
+	words := []string{"Smith", "Thompson", "Schwarzenegger", "Smith-Jones",
+		"", "Dübois"}
+	var res Result
+	for _, enc := range encoders {
+		for _, word := range words {
+			m, m2 := enc.Encode(word)
+			enc.EncodeInto(word, &res)
+			if string(res.Metaph) != m || string(res.Metaph2) != m2 {
+				t.Errorf("%+v %q got: %s, %s;  want: %s, %s", enc, word,
+					res.Metaph, res.Metaph2, m, m2)
+			}
+		}
+	}
+	enc := NewEncoder(6)
+	enc.EncodeInto("Schwarzenegger", &res)
+	if n := testing.AllocsPerRun(100, func() {
+		enc.EncodeInto("Schwarzenegger", &res)
+	}); n != 0 {
+		t.Errorf("got: %v allocations;  want: 0", n)
+	}
+}
+
+func BenchmarkEncodeInto(b *testing.B) {
+	words, err := readFileLines("testInputData.txt.gz")
+	if err != nil {
+		b.Fatalf("%v", err)
+	}
+	enc := NewEncoder(6)
+	var res Result
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		enc.EncodeInto(words[i%len(words)], &res)
+	}
+}
@@ -0,0 +1,71 @@
+// rhyme.go - find words that rhyme.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NewRhymeMap returns a MetaphMap made from wordlist in which MatchWord
+// finds the words that rhyme with a given word rather than those that
+// sound like it.  Words are stored under keys made from the end of the
+// word: the letters of the last vowel sound followed by the
+// DoubleMetaphone code of what follows them, so "light", "bite" and
+// "night" share a key.  A silent final E is not taken as the last vowel.
+func NewRhymeMap(wordlist []string) *MetaphMap {
+	return NewMetaphMapWithOptions(wordlist, 0, &Options{Rhyme: true})
+}
+
+// rhymeCodes returns the primary and secondary rhyme keys of word.
+func rhymeCodes(word string) (key, key2 string) {
+	w := []rune(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) {
+			return unicode.ToUpper(r)
+		}
+		return -1
+	}, word))
+	isVowel := func(i int) bool {
+		return strings.ContainsRune("AEIOUY", w[i]) && (w[i] != 'Y' || i > 0)
+	}
+	end := len(w)
+	if end > 1 && w[end-1] == 'E' {
+		for i := end - 2; i >= 0; i-- {
+			if isVowel(i) {
+				end--
+				break
+			}
+		}
+	}
+	j := -1 // end of the last vowel sound
+	for i := end - 1; i >= 0; i-- {
+		if isVowel(i) {
+			j = i + 1
+			break
+		}
+	}
+	if j < 0 {
+		return
+	}
+	i := j - 1 // start of the last vowel sound
+	for i > 0 && isVowel(i-1) {
+		i--
+	}
+	// The code for the letters after the vowel sound is what the whole
+	// word's code adds to the code for the letters up to its end.
+	maxLen := 3*len(w) + 1
+	p, p2 := DoubleMetaphone(string(w[:j]), maxLen)
+	m, m2 := DoubleMetaphone(string(w), maxLen)
+	vowel := string(w[i:j]) + ":"
+	key = vowel + strings.TrimPrefix(m, p)
+	if len(m2) > 0 {
+		if len(p2) == 0 {
+			p2 = p
+		}
+		if key2 = vowel + strings.TrimPrefix(m2, p2); key2 == key {
+			key2 = ""
+		}
+	}
+	return
+}
@@ -0,0 +1,20 @@
+// rhyme_test.go - test rhyme.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestRhymes(t *testing.T) {
+	words := []string{"light", "bite", "night", "cat", "hat", "fight", "lot"}
+	matches := NewRhymeMap(words).MatchWord("sight")
+	sort.Strings(matches)
+	want := "bite fight light night"
+	if got := strings.Join(matches, " "); got != want {
+		t.Errorf("got: %s;  want: %s", got, want)
+	}
+}
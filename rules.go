@@ -0,0 +1,114 @@
+// rules.go - override Double Metaphone rules at run time.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Rule overrides the built-in rules of DoubleMetaphone for a sequence of
+// letters, such as treating "PH" at the start of a word as "P".  Rules are
+// tried, in order, before the built-in rules at each position of a word;
+// the first that matches adds its codes and consumes its letters.
+type Rule struct {
+	// Match is the sequence of letters the rule applies to.  Case is
+	// ignored.
+	Match string `json:"match"`
+	// At is where Match must be: "start" (the first letters of the
+	// word), "end" (the last letters) or "" or "any" (anywhere).
+	At string `json:"at,omitempty"`
+	// Primary is added to the primary code.
+	Primary string `json:"primary"`
+	// Secondary is added to the secondary code.  It is Primary if empty.
+	// If both are empty, the rule suppresses Match: its letters add
+	// nothing to either code.
+	Secondary string `json:"secondary,omitempty"`
+	// enhanced marks a rule of an EnhancedVersion.
+	enhanced bool
+}
+
+// Rules is a list of Rule overrides for an Encoder.
+type Rules []Rule
+
+// LoadRules reads Rules from r, a JSON object such as
+//
+//	{"rules": [
+//		{"match": "PH", "at": "start", "primary": "P"},
+//		{"match": "GH", "at": "end", "primary": ""}
+//	]}
+//
+// and checks them.  The codes of a rule are upper-cased, as are those
+// DoubleMetaphone makes.
+func LoadRules(r io.Reader) (rules Rules, err error) {
+	var file struct {
+		Rules Rules `json:"rules"`
+	}
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&file); err != nil {
+		err = fmt.Errorf("trying to read rules: %v", err)
+		return
+	}
+	for i, rule := range file.Rules {
+		if len(rule.Match) == 0 {
+			err = fmt.Errorf("rule %d: empty match", i+1)
+			return
+		}
+		switch rule.At {
+		case "", "any", "start", "end":
+		default:
+			err = fmt.Errorf("rule %d: at is %q, not start, end or any",
+				i+1, rule.At)
+			return
+		}
+		rule.Match = strings.ToUpper(rule.Match)
+		rule.Primary = strings.ToUpper(rule.Primary)
+		rule.Secondary = strings.ToUpper(rule.Secondary)
+		rules = append(rules, rule)
+	}
+	return
+}
+
+// LoadRulesFile reads Rules from file fileName as LoadRules does.
+func LoadRulesFile(fileName string) (rules Rules, err error) {
+	var fp *os.File
+	if fp, err = os.Open(fileName); err != nil {
+		err = fmt.Errorf("trying to open file %s: %v", fileName, err)
+		return
+	}
+	defer fp.Close()
+	if rules, err = LoadRules(fp); err != nil {
+		err = fmt.Errorf("file %s: %v", fileName, err)
+	}
+	return
+}
+
+// match returns the first rule of rules that matches upper-cased word at
+// index current, and the number of letters it matches, or 0 if none
+// matches.  last is the index of the word's last letter.  Under Version1
+// it is counted in bytes, not runes, so it can be past the end of word.
+func (rules Rules) match(word []rune, current, last int) (Rule, int) {
+	for _, rule := range rules {
+		n := 0
+		for _, r := range rule.Match {
+			if current+n > last || current+n >= len(word) ||
+				word[current+n] != r {
+				n = 0
+				break
+			}
+			n++
+		}
+		if n == 0 ||
+			rule.At == "start" && current != 0 ||
+			rule.At == "end" && current+n-1 != last {
+			continue
+		}
+		return rule, n
+	}
+	return Rule{}, 0
+}
@@ -0,0 +1,58 @@
+// rules_test.go - test rules.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRules(t *testing.T) {
+	rules, err := LoadRules(strings.NewReader(`{"rules": [
+		{"match": "ph", "at": "start", "primary": "p"},
+		{"match": "GH", "at": "end", "primary": ""},
+		{"match": "J", "primary": "J", "secondary": "H"}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc := &Encoder{MaxLen: 6, Rules: rules}
+	tests := []struct {
+		word, m, m2 string
+	}{
+		{"Phelps", "PLPS", ""},
+		{"Alphonse", "ALFNS", ""},
+		{"Hugh", "H", ""},
+		{"Jose", "JS", "HS"},
+	}
+	for _, tt := range tests {
+		if m, m2 := enc.Encode(tt.word); m != tt.m || m2 != tt.m2 {
+			t.Errorf("%s got: %s, %s;  want: %s, %s", tt.word, m, m2, tt.m, tt.m2)
+		}
+	}
+	for _, bad := range []string{
+		`{"rules": [{"match": "", "primary": "P"}]}`,
+		`{"rules": [{"match": "PH", "at": "middle", "primary": "P"}]}`,
+		`{"rulez": []}`,
+	} {
+		if _, err := LoadRules(strings.NewReader(bad)); err == nil {
+			t.Errorf("%s got nil error", bad)
+		}
+	}
+}
+
+// Under Version1 the last letter of a word is found by its length in
+// bytes, which a word of multibyte letters overstates.
+func TestRulesMultibyte(t *testing.T) {
+	rules := Rules{{Match: "C", Primary: "K"}, {Match: "K", At: "end", Primary: ""}}
+	for _, v := range []AlgorithmVersion{Version1, Version2} {
+		enc := &Encoder{MaxLen: 10, Version: v, Rules: rules}
+		for _, word := range []string{"ＡＢＣ", "ÉÉÉÉÉÉtalk", "ÇÇC", "Müllerc"} {
+			enc.Encode(word)
+		}
+		if m, _ := enc.Encode("Müllerc"); m != "MLRK" {
+			t.Errorf("%v Müllerc got: %s;  want: MLRK", v, m)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// scan.go - find misspelled words in a document.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// Finding is a word of a document that is not in a dictionary.
+type Finding struct {
+	// Word is the word as it appears in the document.
+	Word string
+	// Offset is the byte offset of Word in the document.
+	Offset int64
+	// Suggestions are the dictionary words that sound like Word, best
+	// match first, ranked as for Nearest.
+	Suggestions []string
+}
+
+// ScanDocument splits the document read from r into words with the Split
+// function of dict's Encoder and returns a Finding for each word that has a
+// letter and is not in dict, in document order.  Case is ignored when
+// looking a word up.  A custom Split must return tokens that are slices of
+// its data argument, as the bufio split functions do, for the offsets to
+// be right.
+func ScanDocument(r io.Reader, dict *MetaphMap) (findings []Finding, err error) {
+	if dict == nil {
+		err = fmt.Errorf("ScanDocument: nil dictionary")
+		return
+	}
+	split := dict.enc.Split
+	if split == nil {
+		split = ScanWords
+	}
+	var offset, start int64
+	sc := bufio.NewScanner(r)
+	sc.Split(func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		advance, token, err = split(data, atEOF)
+		if token != nil {
+			start = offset + int64(cap(data)-cap(token))
+		}
+		offset += int64(advance)
+		return
+	})
+	for sc.Scan() {
+		word := sc.Text()
+		if !strings.ContainsFunc(word, unicode.IsLetter) || dict.hasWord(word) {
+			continue
+		}
+		findings = append(findings, Finding{
+			Word:        word,
+			Offset:      start,
+			Suggestions: dict.rank(word, dict.MatchWord(word)),
+		})
+	}
+	if err = sc.Err(); err != nil {
+		err = fmt.Errorf("trying to scan document: %v", err)
+	}
+	return
+}
+
+// hasWord returns true if word is in metaph.  Case is ignored.
+func (metaph *MetaphMap) hasWord(word string) bool {
+	for _, w := range metaph.MatchWord(word) {
+		if strings.EqualFold(w, word) {
+			return true
+		}
+	}
+	return false
+}
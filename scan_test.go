@@ -0,0 +1,29 @@
+// scan_test.go - test scan.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestScanDocument(t *testing.T) {
+	dict := NewMetaphMap([]string{"the", "quick", "brown", "fox", "jumps",
+		"over", "lazy", "dog", "dig"}, 4)
+	doc := "The quik brown fox\njumps ovr the 2 lazy dogg."
+	findings, err := ScanDocument(strings.NewReader(doc), dict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "[{quik 4 [quick]} {ovr 25 [over]} {dogg 40 [dog dig]}]"
+	if got := fmt.Sprint(findings); got != want {
+		t.Errorf("got: %s;  want: %s", got, want)
+	}
+	for _, f := range findings {
+		if doc[f.Offset:f.Offset+int64(len(f.Word))] != f.Word {
+			t.Errorf("%q is not at offset %d", f.Word, f.Offset)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// setops.go - set operations on MetaphMaps.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Words returns the distinct words in metaph, sorted.
+func (metaph *MetaphMap) Words() []string {
+	words := metaph.distinctWords()
+	sort.Strings(words)
+	return words
+}
+
+// Union returns a MetaphMap holding the words of both metaph and other.
+// The result uses metaph's maxLen and options.  An error wrapping
+// ErrIncompatibleMaxLen is returned if metaph and other use different
+// maxLens.
+func (metaph *MetaphMap) Union(other *MetaphMap) (*MetaphMap, error) {
+	if err := metaph.compatible(other); err != nil {
+		return nil, err
+	}
+	out := metaph.filter(func(string) bool { return true })
+	have := make(map[string]bool)
+	for _, w := range metaph.distinctWords() {
+		have[w] = true
+	}
+	for _, w := range other.distinctWords() {
+		if !have[w] {
+			out.add(w)
+		}
+	}
+	return out, nil
+}
+
+// Intersect returns a MetaphMap holding the words of metaph that are also
+// words of other.  See Union for the result's settings and errors.
+func (metaph *MetaphMap) Intersect(other *MetaphMap) (*MetaphMap, error) {
+	return metaph.setOp(other, func(w string, words map[string]bool) bool {
+		return words[w]
+	})
+}
+
+// Difference returns a MetaphMap holding the words of metaph that are not
+// words of other.  See Union for the result's settings and errors.
+func (metaph *MetaphMap) Difference(other *MetaphMap) (*MetaphMap, error) {
+	return metaph.setOp(other, func(w string, words map[string]bool) bool {
+		return !words[w]
+	})
+}
+
+// IntersectCodes returns a MetaphMap holding the words of metaph that
+// sound like a word of other.  See Union for the result's settings and
+// errors.
+func (metaph *MetaphMap) IntersectCodes(other *MetaphMap) (*MetaphMap, error) {
+	return metaph.setOp(other, func(w string, _ map[string]bool) bool {
+		return other.ContainsSoundAlike(w)
+	})
+}
+
+// DifferenceCodes returns a MetaphMap holding the words of metaph that do
+// not sound like any word of other, such as the terms in one dictionary
+// with no sound-alike in another.  See Union for the result's settings
+// and errors.
+func (metaph *MetaphMap) DifferenceCodes(other *MetaphMap) (*MetaphMap, error) {
+	return metaph.setOp(other, func(w string, _ map[string]bool) bool {
+		return !other.ContainsSoundAlike(w)
+	})
+}
+
+// setOp returns a MetaphMap holding the words w of metaph for which
+// keep(w, words) is true, where words holds the words of other.
+func (metaph *MetaphMap) setOp(other *MetaphMap,
+	keep func(w string, words map[string]bool) bool) (*MetaphMap, error) {
+	if err := metaph.compatible(other); err != nil {
+		return nil, err
+	}
+	words := make(map[string]bool)
+	for _, w := range other.distinctWords() {
+		words[w] = true
+	}
+	return metaph.filter(func(w string) bool { return keep(w, words) }), nil
+}
+
+// compatible returns an error if metaph and other make codes that cannot
+// be compared, or if either is nil.
+func (metaph *MetaphMap) compatible(other *MetaphMap) error {
+	if metaph == nil || other == nil {
+		return fmt.Errorf("set operation on a nil MetaphMap")
+	}
+	if metaph.maxlen != other.maxlen || metaph.opts.Rhyme != other.opts.Rhyme {
+		return fmt.Errorf("%w: MetaphMaps with maxLen %d and %d",
+			ErrIncompatibleMaxLen, metaph.maxlen, other.maxlen)
+	}
+	return nil
+}
+
+// filter returns a copy of metaph holding only the words for which keep
+// returns true.
+func (metaph *MetaphMap) filter(keep func(word string) bool) *MetaphMap {
+	out := newMetaphMap(metaph.maxlen, &metaph.opts)
+	for code, bucket := range metaph.mapper {
+		var kept []string
+		for _, w := range bucket {
+			if keep(w) {
+				kept = append(kept, w)
+				out.freq[w] = metaph.freq[w]
+				if out.codes != nil {
+					out.codes[w] = metaph.codes[w]
+				}
+				if m, ok := metaph.meta[w]; ok {
+					if out.meta == nil {
+						out.meta = make(map[string][]Metadata)
+					}
+					out.meta[w] = m
+				}
+			}
+		}
+		if len(kept) > 0 {
+			out.mapper[code] = kept
+		}
+	}
+	return out
+}
@@ -0,0 +1,37 @@
+// setops_test.go - test setops.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSetOps(t *testing.T) {
+	a := NewMetaphMap([]string{"Smith", "Jones", "Brown"}, 4)
+	b := NewMetaphMap([]string{"Smyth", "Jones", "Green"}, 4)
+	tests := []struct {
+		op   func(*MetaphMap) (*MetaphMap, error)
+		want string
+	}{
+		{a.Union, "[Brown Green Jones Smith Smyth]"},
+		{a.Intersect, "[Jones]"},
+		{a.Difference, "[Brown Smith]"},
+		{a.IntersectCodes, "[Jones Smith]"},
+		{a.DifferenceCodes, "[Brown]"},
+	}
+	for i, tt := range tests {
+		m, err := tt.op(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fmt.Sprint(m.Words()); got != tt.want {
+			t.Errorf("op %d got: %s;  want: %s", i, got, tt.want)
+		}
+	}
+	if _, err := a.Union(NewMetaphMap(nil, 6)); !errors.Is(err, ErrIncompatibleMaxLen) {
+		t.Errorf("got: %v;  want ErrIncompatibleMaxLen", err)
+	}
+}
@@ -0,0 +1,122 @@
+// shingle.go - phonetic shingles for phrase similarity.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Shingles is like (*Encoder).Shingles for an Encoder with a MaxLen of 4.
+func Shingles(phrase string, n int) []string {
+	return (&Encoder{}).Shingles(phrase, n)
+}
+
+// Shingles returns the distinct n-gram shingles of the primary codes of
+// the words of phrase, split and encoded as by EncodePhrase, in order of
+// first appearance.  Each shingle joins the codes of n consecutive words
+// with spaces, so the shingles of "Hey Jude" for n = 2 are ["H JT"].  A
+// phrase of fewer than n words has one shingle of all its words.  Words
+// with no code, such as "!", are skipped.  n is 1 if less than 1.
+func (enc *Encoder) Shingles(phrase string, n int) (shingles []string) {
+	var codes []string
+	for _, w := range enc.EncodePhrase(phrase).Words {
+		if len(w.Metaph) > 0 {
+			codes = append(codes, w.Metaph)
+		}
+	}
+	return shingle(codes, n)
+}
+
+// shingle returns the distinct n-gram shingles of codes, as described for
+// Shingles.
+func shingle(codes []string, n int) (shingles []string) {
+	if len(codes) == 0 {
+		return
+	}
+	n = min(max(n, 1), len(codes))
+	seen := make(map[string]bool)
+	for i := 0; i+n <= len(codes); i++ {
+		s := strings.Join(codes[i:i+n], " ")
+		if !seen[s] {
+			seen[s] = true
+			shingles = append(shingles, s)
+		}
+	}
+	return
+}
+
+// PhraseSimilarity is like (*Encoder).PhraseSimilarity for an Encoder with
+// a MaxLen of 4.
+func PhraseSimilarity(a, b string, n int) float64 {
+	return (&Encoder{}).PhraseSimilarity(a, b, n)
+}
+
+// PhraseSimilarity returns the Jaccard similarity of the n-gram Shingles
+// of phrases a and b: the number of shingles they share divided by the
+// number of distinct shingles of both.  It is 1 for phrases that sound
+// alike word for word, such as "Hey Jude" and "Hay Jood", and 0 for
+// phrases with no shingle in common or with no shingles.  Song titles and
+// other short phrases match well with n = 1 or 2.
+func (enc *Encoder) PhraseSimilarity(a, b string, n int) float64 {
+	return jaccard(enc.Shingles(a, n), enc.Shingles(b, n))
+}
+
+// jaccard returns the Jaccard similarity of sets of distinct strings sa
+// and sb, or 0 if both are empty.
+func jaccard(sa, sb []string) float64 {
+	inA := make(map[string]bool)
+	for _, s := range sa {
+		inA[s] = true
+	}
+	shared := 0
+	for _, s := range sb {
+		if inA[s] {
+			shared++
+		}
+	}
+	union := len(sa) + len(sb) - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+// documentShingle is the number of words in a shingle of a document.
+const documentShingle = 3
+
+// DocumentSimilarity is like (*Encoder).DocumentSimilarity for an Encoder
+// with a MaxLen of 4.
+func DocumentSimilarity(a, b io.Reader) float64 {
+	return (&Encoder{}).DocumentSimilarity(a, b)
+}
+
+// DocumentSimilarity returns the Jaccard similarity of the three-word
+// phonetic shingles of documents a and b, as PhraseSimilarity does for
+// phrases, for finding near-duplicates among transcribed or dictated
+// documents whose spelling differs but whose sound does not.  It is near
+// 1 for near-duplicates and near 0 for unrelated documents.  A read error
+// ends a document early.
+func (enc *Encoder) DocumentSimilarity(a, b io.Reader) float64 {
+	return jaccard(enc.documentShingles(a), enc.documentShingles(b))
+}
+
+// documentShingles returns the shingles of the words of r, split by
+// enc.Split.
+func (enc *Encoder) documentShingles(r io.Reader) []string {
+	split := ScanWords
+	if enc.Split != nil {
+		split = enc.Split
+	}
+	sc := bufio.NewScanner(r)
+	sc.Split(split)
+	var codes []string
+	for sc.Scan() {
+		if m, _ := enc.Encode(sc.Text()); len(m) > 0 {
+			codes = append(codes, m)
+		}
+	}
+	return shingle(codes, documentShingle)
+}
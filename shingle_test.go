@@ -0,0 +1,63 @@
+// shingle_test.go - test shingle.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestShingles(t *testing.T) {
+	tests := []struct {
+		phrase string
+		n      int
+		want   string
+	}{
+		{"Hey Jude", 2, "[H JT]"},
+		{"Hey Jude", 1, "[H JT]"},
+		{"Hey Jude", 5, "[H JT]"},
+		{"Let It Be, Let It Be", 2, "[LT AT AT P P LT]"},
+		{"!", 2, "[]"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprint(Shingles(tt.phrase, tt.n)); got != tt.want {
+			t.Errorf("%q %d got: %s;  want: %s", tt.phrase, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestPhraseSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		n    int
+		want float64
+	}{
+		{"Hey Jude", "Hay Jood", 2, 1},
+		{"Yesterday", "Tomorrow", 1, 0},
+		{"Let It Be", "Let It Bee Now", 2, 2.0 / 3},
+		{"", "", 2, 0},
+	}
+	for _, tt := range tests {
+		if got := PhraseSimilarity(tt.a, tt.b, tt.n); got != tt.want {
+			t.Errorf("%q %q got: %v;  want: %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestDocumentSimilarity(t *testing.T) {
+	a := "The patient was given two tablets of acetaminophen at night " +
+		"and reported less pain in the morning."
+	b := "The pasient was givin too tablets of asetaminofen at nite " +
+		"and reported les pane in the mourning."
+	c := "Quarterly revenue rose on strong demand for cloud services."
+	if got := DocumentSimilarity(strings.NewReader(a),
+		strings.NewReader(b)); got < 0.7 {
+		t.Errorf("got: %v;  want at least 0.7", got)
+	}
+	if got := DocumentSimilarity(strings.NewReader(a),
+		strings.NewReader(c)); got != 0 {
+		t.Errorf("got: %v;  want: 0", got)
+	}
+}
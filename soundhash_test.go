@@ -0,0 +1,39 @@
+// soundhash_test.go - test soundhash.go.
+// This file is public domain.
+
+package metaphone
+
+import "testing"
+
+func TestSoundHash(t *testing.T) {
+	hashes := map[string]uint64{
+		"":      0,
+		"Jon":   0x0500000000000012,
+		"Bryan": 0x48000000a1010012,
+		"Smith": 0x1400000002011d04,
+	}
+	for word, want := range hashes {
+		if got := SoundHash(word); got != want {
+			t.Errorf("%q got: %016x;  want: %016x", word, got, want)
+		}
+	}
+	for _, p := range [][2]string{{"Bryan", "Brian"}, {"Smith", "SMYTH"},
+		{"Jon", "Jan"}} {
+		if d := SoundHashDistance(SoundHash(p[0]), SoundHash(p[1])); d != 0 {
+			t.Errorf("%s %s got: %d;  want: 0", p[0], p[1], d)
+		}
+	}
+	near := [][4]string{
+		{"Jon", "John", "Jon", "Smith"},
+		{"Catherine", "Katherine", "Catherine", "Robert"},
+		{"Philip", "Filip", "Philip", "Henry"},
+	}
+	for _, q := range near {
+		d1 := SoundHashDistance(SoundHash(q[0]), SoundHash(q[1]))
+		d2 := SoundHashDistance(SoundHash(q[2]), SoundHash(q[3]))
+		if d1 >= d2 {
+			t.Errorf("%s-%s got: %d;  want less than %s-%s: %d", q[0], q[1],
+				d1, q[2], q[3], d2)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// source.go - read word lists from any storage.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// Source is a word list in some storage, such as a file, an object store,
+// a database or an asset embedded in a program.  Builder's FromSource and
+// NewMetaphMapFromSource read word lists from any Source, so a new kind
+// of storage needs only a Source, not new constructors.
+type Source interface {
+	// Open returns a reader of the word list, which the caller closes.
+	Open() (io.ReadCloser, error)
+	// Name names the word list in errors.  A name ending with ".gz"
+	// marks a gzipped word list, and one ending with ".zip" a zip archive
+	// of word list files, which can also be gzipped.
+	Name() string
+}
+
+// FileSource returns a Source for file fileName.
+func FileSource(fileName string) Source {
+	return fileSource(fileName)
+}
+
+// fileSource is the Source of a file.
+type fileSource string
+
+// Open opens the file.
+func (s fileSource) Open() (io.ReadCloser, error) {
+	fp, err := os.Open(string(s))
+	if err != nil {
+		return nil, fmt.Errorf("trying to open file %s: %v", string(s), err)
+	}
+	return fp, nil
+}
+
+// Name returns the file's name.
+func (s fileSource) Name() string {
+	return string(s)
+}
+
+// FSSource returns a Source for file name in fsys, such as an embed.FS.
+func FSSource(fsys fs.FS, name string) Source {
+	return fsSource{fsys, name}
+}
+
+// fsSource is the Source of a file in an fs.FS.
+type fsSource struct {
+	fsys fs.FS
+	name string
+}
+
+// Open opens the file.
+func (s fsSource) Open() (io.ReadCloser, error) {
+	f, err := s.fsys.Open(s.name)
+	if err != nil {
+		return nil, fmt.Errorf("trying to open file %s: %v", s.name, err)
+	}
+	return f, nil
+}
+
+// Name returns the file's name.
+func (s fsSource) Name() string {
+	return s.name
+}
+
+// NewSource returns a Source named name whose Open calls open, for word
+// lists in storage that has no Source of its own.
+func NewSource(name string, open func() (io.ReadCloser, error)) Source {
+	return funcSource{name, open}
+}
+
+// funcSource is the Source made by NewSource.
+type funcSource struct {
+	name string
+	open func() (io.ReadCloser, error)
+}
+
+// Open calls the Source's open function.
+func (s funcSource) Open() (io.ReadCloser, error) {
+	return s.open()
+}
+
+// Name returns the Source's name.
+func (s funcSource) Name() string {
+	return s.name
+}
+
+// NewMetaphMapFromSource returns a MetaphMap made from the word list of
+// src, as NewMetaphMapFromFile does from a file.
+func NewMetaphMapFromSource(src Source, maxLen int) (metaph *MetaphMap,
+	err error) {
+	return NewBuilder().MaxLen(maxLen).FromSource(src).Build()
+}
+
+// readSource returns the words of the word list of src, in the character
+// encoding and checked per opts, as splitWordlist returns them.  If src
+// is a zip archive, only its members that match one of patterns, as
+// matchMember tells, are read.
+func readSource(src Source, patterns []string, opts Options) (lines []string,
+	err error) {
+	var rc io.ReadCloser
+	var r io.Reader
+	var b []byte
+	name := src.Name()
+
+	if rc, err = src.Open(); err != nil {
+		return
+	}
+	defer rc.Close()
+	r = rc
+	if strings.HasSuffix(name, ".gz") {
+		if r, err = gzip.NewReader(r); err != nil {
+			err = fmt.Errorf(
+				"trying to make a gzip reader for file %s: %v", name, err)
+			return
+		}
+	}
+	if b, err = io.ReadAll(r); err != nil {
+		err = fmt.Errorf("trying to read file %s: %v", name, err)
+		return
+	}
+	if strings.HasSuffix(name, ".zip") {
+		var zr *zip.Reader
+		if zr, err = zip.NewReader(bytes.NewReader(b),
+			int64(len(b))); err != nil {
+			err = fmt.Errorf("trying to read zip file %s: %v", name, err)
+			return
+		}
+		return readZipWordlist(zr, name, patterns, opts)
+	}
+	var text string
+	var line int
+	if text, line, err = decodeCharset(b, opts.Charset); err != nil {
+		err = fmt.Errorf("%w: trying to decode file %s: %v",
+			ErrDictionaryFormat, name, err)
+		return
+	}
+	return splitWordlist(text, name, line, opts)
+}
+
+// splitWordlist returns the words of word list text, one per line, which
+// is named name in errors and starts at line line of its file.  A
+// carriage return ending a line, leading and trailing whitespace, and
+// blank lines, which would otherwise change the codes of words or add an
+// empty word, are removed, or, if opts.StrictWordlist is true, are an
+// error that wraps ErrDictionaryFormat.  A line feed ending the last line
+// is not a blank line.  If opts.Encoder.Strict is true, a word with a
+// character that EncodeStrict rejects is an error that wraps
+// ErrDictionaryFormat and ErrUnsupportedChar.  Errors give the number of
+// the line in the file.
+func splitWordlist(text, name string, line int, opts Options) (
+	words []string, err error) {
+	if len(text) == 0 {
+		return
+	}
+	enc := opts.Encoder
+	for i, l := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		word := strings.TrimSpace(l)
+		if opts.StrictWordlist && (word != l || len(word) == 0) {
+			problem := "leading or trailing whitespace"
+			switch {
+			case len(word) == 0:
+				problem = "blank line"
+			case strings.TrimSuffix(l, "\r") == word:
+				problem = "CRLF line ending"
+			}
+			err = fmt.Errorf("%w: line %d of file %s: %s",
+				ErrDictionaryFormat, line+i, name, problem)
+			return
+		}
+		if len(word) == 0 {
+			continue
+		}
+		if enc != nil && enc.Strict {
+			if err = checkAlphabet(word, enc.Digits,
+				enc.OtherPunct != PunctKeep); err != nil {
+				err = fmt.Errorf("%w: line %d of file %s: %w",
+					ErrDictionaryFormat, line+i, name, err)
+				return
+			}
+		}
+		words = append(words, word)
+	}
+	return
+}
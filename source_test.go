@@ -0,0 +1,109 @@
+// source_test.go - test source.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestSource(t *testing.T) {
+	fsys := fstest.MapFS{"dict/words.txt": {Data: []byte("Smith\nSchmidt\n")}}
+	opened := 0
+	db := NewSource("db:words", func() (io.ReadCloser, error) {
+		opened++
+		return io.NopCloser(strings.NewReader("Smyth\n")), nil
+	})
+	metaph, err := NewBuilder().FromSource(FSSource(fsys, "dict/words.txt")).
+		FromSource(db).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := metaph.MatchWord("Smith")
+	sort.Strings(got)
+	if want := "[Schmidt Smith Smyth]"; fmt.Sprint(got) != want || opened != 1 {
+		t.Errorf("got: %v, opened %d;  want: %s, opened 1", got, opened, want)
+	}
+
+	metaph, err = NewMetaphMapFromSource(db, 4)
+	if err != nil || metaph.Len() != 2 {
+		t.Errorf("NewMetaphMapFromSource got Len %d, %v;  want 2, nil",
+			metaph.Len(), err)
+	}
+
+	errOpen := errors.New("no connection")
+	bad := NewSource("db:none", func() (io.ReadCloser, error) {
+		return nil, errOpen
+	})
+	if _, err = NewMetaphMapFromSource(bad, 4); !errors.Is(err, errOpen) {
+		t.Errorf("got: %v;  want: %v", err, errOpen)
+	}
+	if _, err = NewMetaphMapFromSource(FSSource(fsys, "none.txt"), 4); err == nil {
+		t.Errorf("missing file got nil error")
+	}
+}
+
+func TestSplitWordlist(t *testing.T) {
+	tests := []struct {
+		text, want string
+		problem    string
+	}{
+		{"Smith\nJones\n", "[Smith Jones]", ""},
+		{"Smith\r\nJones\r\n", "[Smith Jones]", "line 1 of file w: CRLF line ending"},
+		{"Smith \nJones", "[Smith Jones]", "line 1 of file w: leading or trailing whitespace"},
+		{"Smith\n\n  \nJones\n", "[Smith Jones]", "line 2 of file w: blank line"},
+		{"", "[]", ""},
+	}
+	for _, tt := range tests {
+		words, err := splitWordlist(tt.text, "w", 1, Options{})
+		if got := fmt.Sprint(words); got != tt.want || err != nil {
+			t.Errorf("%q got: %s, %v;  want: %s", tt.text, got, err, tt.want)
+		}
+		_, err = splitWordlist(tt.text, "w", 1,
+			Options{StrictWordlist: true})
+		switch {
+		case tt.problem == "" && err != nil:
+			t.Errorf("%q strict got: %v;  want: nil", tt.text, err)
+		case tt.problem != "" && (!errors.Is(err, ErrDictionaryFormat) ||
+			!strings.HasSuffix(err.Error(), tt.problem)):
+			t.Errorf("%q strict got: %v;  want: %s", tt.text, err, tt.problem)
+		}
+	}
+	src := NewSource("crlf.txt", func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("Thomas\r\nTomas\r\n\r\n")), nil
+	})
+	metaph, err := NewBuilder().FromSource(src).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(metaph.Words()); got != "[Thomas Tomas]" {
+		t.Errorf("got: %s;  want: [Thomas Tomas]", got)
+	}
+	_, err = NewBuilder().StrictWordlist().FromSource(src).Build()
+	if !errors.Is(err, ErrDictionaryFormat) {
+		t.Errorf("strict got: %v;  want: %v", err, ErrDictionaryFormat)
+	}
+}
+
+func TestStrictWordlistLineNumbers(t *testing.T) {
+	src := NewSource("words.txt", func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(
+			"# encoding: utf-8\nalpha\n\n\nbeta\nga9mma\n")), nil
+	})
+	_, err := NewBuilder().Encoder(&Encoder{Strict: true}).FromSource(src).Build()
+	if !errors.Is(err, ErrUnsupportedChar) ||
+		!strings.Contains(err.Error(), "line 6 of file words.txt") {
+		t.Errorf("got: %v;  want: line 6 of file words.txt", err)
+	}
+	_, err = NewBuilder().StrictWordlist().FromSource(src).Build()
+	if !errors.Is(err, ErrDictionaryFormat) ||
+		!strings.Contains(err.Error(), "line 3 of file words.txt: blank line") {
+		t.Errorf("got: %v;  want: line 3 of file words.txt: blank line", err)
+	}
+}
@@ -0,0 +1,120 @@
+// spanish.go - a Metaphone for Spanish.
+// Created 2026-10-16 and placed in the public domain.
+//
+// The rules follow the Spanish Metaphone of Alejandro Mosquera, with the
+// seseo of Latin American Spanish left to the caller: Z and soft C both
+// encode as Z.
+
+package metaphone
+
+import "strings"
+
+// spanishFold maps accented letters to the letters they are encoded as.
+var spanishFold = strings.NewReplacer("Á", "A", "É", "E", "Í", "I", "Ó", "O",
+	"Ú", "U", "Ü", "U", "Ñ", "NY")
+
+// SpanishMetaphone returns a code of at most maxLen characters for word
+// that is the same for Spanish words that sound alike, such as "Jiménez"
+// and "Giménez" or "Vargas" and "Bargas".  Argument maxLen is 4 if less
+// than 1.  Case and characters other than letters are ignored.  Its codes
+// cannot be compared with DoubleMetaphone codes, so they should not be
+// stored in a MetaphMap with them; EncodeLang gives Spanish codes that
+// can.
+func SpanishMetaphone(word string, maxLen int) string {
+	if maxLen < 1 {
+		maxLen = 4
+	}
+	var letters []byte
+	for _, r := range spanishFold.Replace(strings.ToUpper(word)) {
+		if 'A' <= r && r <= 'Z' {
+			letters = append(letters, byte(r))
+		}
+	}
+	at := func(i int) byte {
+		if i < 0 || i >= len(letters) {
+			return 0
+		}
+		return letters[i]
+	}
+	isVowel := func(c byte) bool {
+		return c != 0 && strings.IndexByte("AEIOU", c) >= 0
+	}
+	var code strings.Builder
+	initial := true // a vowel here is the word's first sound
+	for i := 0; i < len(letters) && code.Len() < maxLen; i++ {
+		c, next := letters[i], at(i+1)
+		if i > 0 && c == at(i-1) && c != 'C' {
+			continue // double letters, such as RR, sound as one
+		}
+		first := initial
+		initial = false
+		switch c {
+		case 'A', 'E', 'I', 'O', 'U':
+			if first {
+				code.WriteByte(c)
+			}
+		case 'B', 'V':
+			code.WriteByte('B')
+		case 'C':
+			switch {
+			case next == 'E' || next == 'I':
+				code.WriteByte('Z')
+			case next == 'H':
+				code.WriteByte('X')
+				i++
+			default:
+				code.WriteByte('K')
+			}
+		case 'G':
+			if next == 'E' || next == 'I' {
+				code.WriteByte('J')
+				break
+			}
+			code.WriteByte('G')
+			if next == 'U' && (at(i+2) == 'E' || at(i+2) == 'I') {
+				i++ // the U of "GUE" and "GUI" is silent
+			}
+		case 'H':
+			initial = first // silent, so "Hola" starts with its O
+		case 'L':
+			if next == 'L' {
+				code.WriteByte('Y')
+				i++
+			} else {
+				code.WriteByte('L')
+			}
+		case 'Q':
+			code.WriteByte('K')
+			if next == 'U' {
+				i++
+			}
+		case 'S':
+			if first && next != 0 && !isVowel(next) {
+				code.WriteString("ES") // "Stefan" sounds like "Estefan"
+			} else {
+				code.WriteByte('S')
+			}
+		case 'W':
+			code.WriteByte('U')
+		case 'X':
+			if first {
+				code.WriteByte('S')
+			} else {
+				code.WriteString("KS")
+			}
+		case 'Y':
+			if isVowel(next) {
+				code.WriteByte('Y')
+			} else if first {
+				code.WriteByte('I')
+			}
+		default: // D F J K M N P R T Z
+			code.WriteByte(c)
+		}
+	}
+	s := code.String()
+	if len(s) > maxLen {
+		s = s[:maxLen]
+	}
+	return s
+}
@@ -0,0 +1,123 @@
+// stats.go - statistics about a MetaphMap.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import "sort"
+
+// CollisionReport describes how the words of a MetaphMap share codes.
+type CollisionReport struct {
+	MaxLen int
+	Words  int // distinct words
+	Codes  int // distinct codes
+	// Sizes maps a bucket size, the number of distinct words with a code,
+	// to the number of codes with buckets of that size.
+	Sizes map[int]int
+	// Largest holds the largest buckets, largest first.
+	Largest []Group
+	// Rate is the fraction of words that share a code with another word.
+	Rate float64
+}
+
+// Collisions reports how the words in metaph share codes and, for each
+// of maxLens, how they would share codes in a MetaphMap made from the
+// same words and options with that maxLen.  Each report lists the top
+// largest buckets, none if top is less than 1.  Comparing reports helps
+// choose maxLen empirically.
+func (metaph *MetaphMap) Collisions(top int, maxLens ...int) []CollisionReport {
+	if metaph == nil {
+		return nil
+	}
+	reports := []CollisionReport{metaph.collisions(top)}
+	if len(maxLens) > 0 {
+		words := metaph.distinctWords()
+		for _, maxLen := range maxLens {
+			m := NewMetaphMapWithOptions(words, maxLen, &metaph.opts)
+			reports = append(reports, m.collisions(top))
+		}
+	}
+	return reports
+}
+
+// collisions returns a CollisionReport for metaph.
+func (metaph *MetaphMap) collisions(top int) (r CollisionReport) {
+	r.MaxLen = metaph.maxlen
+	r.Codes = len(metaph.mapper)
+	r.Sizes = make(map[int]int)
+	groups := metaph.Groups(1)
+	colliding := make(map[string]bool)
+	for _, g := range groups {
+		r.Sizes[len(g.Words)]++
+		if len(g.Words) > 1 {
+			for _, w := range g.Words {
+				colliding[w] = true
+			}
+		}
+	}
+	r.Words = len(metaph.distinctWords())
+	if r.Words > 0 {
+		r.Rate = float64(len(colliding)) / float64(r.Words)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return len(groups[i].Words) > len(groups[j].Words)
+	})
+	groups = groups[:min(len(groups), max(top, 0))]
+	r.Largest = groups
+	return
+}
+
+// distinctWords returns the distinct words in metaph in no special order.
+func (metaph *MetaphMap) distinctWords() (words []string) {
+	if metaph == nil {
+		return
+	}
+	seen := make(map[string]bool)
+	for _, bucket := range metaph.mapper {
+		for _, w := range bucket {
+			if !seen[w] {
+				seen[w] = true
+				words = append(words, w)
+			}
+		}
+	}
+	return
+}
+
+// MapStats holds counts and sizes for a MetaphMap.
+type MapStats struct {
+	Words     int     // distinct words
+	Entries   int     // words in all buckets, counting each code of a word
+	Codes     int     // distinct codes, i.e. buckets
+	AvgBucket float64 // mean entries per bucket
+	MaxBucket int     // entries in the largest bucket
+	// Bytes approximates the memory used by the map, its buckets and
+	// the words' bytes, assuming a 64-bit platform.
+	Bytes int
+}
+
+// Approximate per-item memory costs on a 64-bit platform.
+const (
+	mapEntryBytes = 16 + 24 + 8 // key and value headers plus overhead
+	stringBytes   = 16          // string header in a bucket
+)
+
+// Stats returns counts and sizes for metaph, for capacity planning.
+func (metaph *MetaphMap) Stats() (s MapStats) {
+	if metaph == nil {
+		return
+	}
+	s.Codes = len(metaph.mapper)
+	for code, bucket := range metaph.mapper {
+		s.Entries += len(bucket)
+		s.MaxBucket = max(s.MaxBucket, len(bucket))
+		s.Bytes += mapEntryBytes + len(code) + cap(bucket)*stringBytes
+	}
+	for _, w := range metaph.distinctWords() {
+		s.Words++
+		s.Bytes += len(w)
+	}
+	if s.Codes > 0 {
+		s.AvgBucket = float64(s.Entries) / float64(s.Codes)
+	}
+	return
+}
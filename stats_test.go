@@ -0,0 +1,40 @@
+// stats_test.go - test stats.go.
+// This file is public domain.
+
+package metaphone
+
+import "testing"
+
+func TestCollisions(t *testing.T) {
+	words := []string{"Smith", "Smyth", "Smithson", "Jones", "Johnson"}
+	reports := NewMetaphMap(words, 2).Collisions(1, 6)
+	if len(reports) != 2 {
+		t.Fatalf("got %d reports;  want 2", len(reports))
+	}
+	short, long := reports[0], reports[1]
+	if short.MaxLen != 2 || long.MaxLen != 6 || short.Words != 5 {
+		t.Errorf("got: %+v, %+v", short, long)
+	}
+	// With maxLen 2 every word collides; with 6 only Smith and Smyth do.
+	if short.Rate != 1 || long.Rate != 0.4 {
+		t.Errorf("got rates %v and %v;  want 1 and 0.4", short.Rate, long.Rate)
+	}
+	if len(short.Largest) != 1 || len(short.Largest[0].Words) != 3 {
+		t.Errorf("got largest: %v", short.Largest)
+	}
+	for _, top := range []int{0, -1} {
+		r := NewMetaphMap(words, 2).Collisions(top)
+		if len(r) != 1 || len(r[0].Largest) != 0 || r[0].Words != 5 {
+			t.Errorf("top %d got: %+v", top, r)
+		}
+	}
+}
+
+func TestStats(t *testing.T) {
+	s := NewMetaphMap([]string{"Smith", "Smyth", "Jones"}, 4).Stats()
+	// Smith and Smyth are under SM0 and XMT, Jones under JNS and ANS.
+	if s.Words != 3 || s.Entries != 6 || s.Codes != 4 || s.MaxBucket != 2 ||
+		s.AvgBucket != 1.5 || s.Bytes <= 0 {
+		t.Errorf("got: %+v", s)
+	}
+}
@@ -0,0 +1,58 @@
+// stream.go - match streams of words against a MetaphMap.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"context"
+	"iter"
+)
+
+// Match is a word and the words of a MetaphMap that sound like it.
+type Match struct {
+	Word    string
+	Matches []string
+}
+
+// MatchStream matches each word received from in against metaph and sends
+// the results, in order, on the returned channel, which is closed when in
+// is closed or ctx is done.  It suits pipelines whose input does not fit
+// in memory.
+func (metaph *MetaphMap) MatchStream(ctx context.Context,
+	in <-chan string) <-chan Match {
+	out := make(chan Match)
+	go func() {
+		defer close(out)
+		for {
+			var word string
+			var ok bool
+			select {
+			case <-ctx.Done():
+				return
+			case word, ok = <-in:
+				if !ok {
+					return
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- Match{word, metaph.MatchWord(word)}:
+			}
+		}
+	}()
+	return out
+}
+
+// MatchSeq returns an iterator over each word of words and the words of
+// metaph that sound like it.  Words are read from words only as the
+// iterator is advanced.
+func (metaph *MetaphMap) MatchSeq(words iter.Seq[string]) iter.Seq2[string, []string] {
+	return func(yield func(string, []string) bool) {
+		for word := range words {
+			if !yield(word, metaph.MatchWord(word)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// stream_test.go - test stream.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestMatchStream(t *testing.T) {
+	metaph := NewMetaphMap([]string{"Smith", "Jones"}, 4)
+	in := make(chan string)
+	go func() {
+		for _, w := range []string{"Smyth", "Xyzzy", "Johns"} {
+			in <- w
+		}
+		close(in)
+	}()
+	var got []string
+	for m := range metaph.MatchStream(context.Background(), in) {
+		got = append(got, fmt.Sprint(m.Word, m.Matches))
+	}
+	want := "[Smyth[Smith] Xyzzy[] Johns[Jones]]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("got: %v;  want: %s", got, want)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, ok := <-metaph.MatchStream(ctx, make(chan string)); ok {
+		t.Errorf("canceled stream sent a Match")
+	}
+}
+
+func TestMatchSeq(t *testing.T) {
+	metaph := NewMetaphMap([]string{"Smith", "Jones"}, 4)
+	var got []string
+	words := slices.Values([]string{"Smyth", "Johns", "Xyzzy"})
+	for word, matches := range metaph.MatchSeq(words) {
+		got = append(got, fmt.Sprint(word, matches))
+		if len(got) == 2 {
+			break
+		}
+	}
+	if want := "[Smyth[Smith] Johns[Jones]]"; fmt.Sprint(got) != want {
+		t.Errorf("got: %v;  want: %s", got, want)
+	}
+}
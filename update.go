@@ -0,0 +1,99 @@
+// update.go - change the words of a MetaphMap in place.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import "strings"
+
+// RemoveWhere removes from metaph each word for which remove returns true,
+// and returns the number of distinct words removed.  remove is called
+// once per distinct word.  It lets a service drop, for example, the words
+// of a revoked dictionary without rebuilding metaph.
+func (metaph *MetaphMap) RemoveWhere(remove func(word string) bool) (n int) {
+	if metaph == nil {
+		return
+	}
+	metaph.sorted, metaph.sortedWords = nil, nil
+	removed := make(map[string]bool)
+	for code, bucket := range metaph.mapper {
+		kept := bucket[:0]
+		for _, w := range bucket {
+			r, seen := removed[w]
+			if !seen {
+				r = remove(w)
+				removed[w] = r
+				if r {
+					n++
+				}
+			}
+			if !r {
+				kept = append(kept, w)
+			}
+		}
+		if len(kept) == 0 {
+			delete(metaph.mapper, code)
+		} else {
+			metaph.mapper[code] = kept
+		}
+	}
+	for w, r := range removed {
+		if r {
+			delete(metaph.freq, w)
+			delete(metaph.meta, w)
+			delete(metaph.codes, w)
+			if metaph.canon != nil {
+				delete(metaph.canon, strings.ToLower(w))
+			}
+		}
+	}
+	return
+}
+
+// RemoveWord removes word from metaph, as stored per metaph's CasePolicy,
+// and returns true if it was there.  With Options.ReverseIndex it takes
+// time proportional to the size of the word's buckets; otherwise it scans
+// every bucket, as RemoveWhere does.
+func (metaph *MetaphMap) RemoveWord(word string) bool {
+	if metaph == nil {
+		return false
+	}
+	if metaph.canon != nil {
+		w, ok := metaph.canon[strings.ToLower(word)]
+		if !ok {
+			return false
+		}
+		word = w
+	}
+	if metaph.codes == nil {
+		return metaph.RemoveWhere(func(w string) bool { return w == word }) > 0
+	}
+	codes, ok := metaph.codes[word]
+	if !ok {
+		return false
+	}
+	metaph.sorted, metaph.sortedWords = nil, nil
+	for _, code := range codes {
+		bucket, ok := metaph.mapper[code]
+		if len(code) == 0 || !ok {
+			continue
+		}
+		kept := bucket[:0]
+		for _, w := range bucket {
+			if w != word {
+				kept = append(kept, w)
+			}
+		}
+		if len(kept) == 0 {
+			delete(metaph.mapper, code)
+		} else {
+			metaph.mapper[code] = kept
+		}
+	}
+	delete(metaph.codes, word)
+	delete(metaph.freq, word)
+	delete(metaph.meta, word)
+	if metaph.canon != nil {
+		delete(metaph.canon, strings.ToLower(word))
+	}
+	return true
+}
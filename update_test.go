@@ -0,0 +1,61 @@
+// update_test.go - test update.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestRemoveWhere(t *testing.T) {
+	metaph, _ := NewBuilder().Case(CaseFold).
+		Words("Smith", "smith", "Smyth", "Schmidt", "Jones").Build()
+	calls := 0
+	n := metaph.RemoveWhere(func(w string) bool {
+		calls++
+		return strings.HasPrefix(w, "Sm")
+	})
+	if n != 2 || calls != 4 {
+		t.Errorf("got n: %d, calls: %d;  want 2, 4", n, calls)
+	}
+	got := metaph.MatchWord("Smith")
+	sort.Strings(got)
+	if fmt.Sprint(got) != "[Schmidt]" || metaph.Len() != 4 {
+		t.Errorf("got: %v, Len %d;  want [Schmidt], Len 4", got, metaph.Len())
+	}
+	metaph.add("SMITH")
+	if got := metaph.MatchWord("Smith"); len(got) != 2 {
+		t.Errorf("re-added got: %v", got)
+	}
+}
+
+func TestRemoveWord(t *testing.T) {
+	for _, reverse := range []bool{false, true} {
+		b := NewBuilder().Case(CaseFold).Words("Smith", "Smyth", "Schmidt", "Jones")
+		if reverse {
+			b.ReverseIndex()
+		}
+		metaph, _ := b.Build()
+		if !metaph.RemoveWord("SMITH") {
+			t.Errorf("reverse %v: SMITH not removed", reverse)
+		}
+		if metaph.RemoveWord("Smith") || metaph.RemoveWord("Brown") {
+			t.Errorf("reverse %v: removed a word not there", reverse)
+		}
+		got := metaph.MatchWord("Smith")
+		sort.Strings(got)
+		if fmt.Sprint(got) != "[Schmidt Smyth]" {
+			t.Errorf("reverse %v got: %v;  want: [Schmidt Smyth]", reverse, got)
+		}
+		metaph.RemoveWord("Jones")
+		if _, ok := metaph.freq["Jones"]; ok || metaph.Len() != 3 {
+			t.Errorf("reverse %v: Jones kept, Len %d", reverse, metaph.Len())
+		}
+		if reverse && len(metaph.codes) != 2 {
+			t.Errorf("reverse index got: %v", metaph.codes)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// validate.go - find problems in a word list before it is used.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// IssueKind is a kind of problem ValidateWordlist finds.
+type IssueKind int
+
+const (
+	// IssueDuplicate is a line that repeats an earlier line exactly.
+	IssueDuplicate IssueKind = iota
+	// IssueNonAlphabetic is a word with a character that EncodeStrict
+	// rejects, such as a digit or symbol.
+	IssueNonAlphabetic
+	// IssueEncoding is a line that is not valid UTF-8 or that holds a
+	// NUL, a byte order mark or the Unicode replacement character.
+	IssueEncoding
+	// IssueShort is a one-letter word other than "a" or "I".
+	IssueShort
+)
+
+// String returns the name of k.
+func (k IssueKind) String() string {
+	switch k {
+	case IssueDuplicate:
+		return "duplicate"
+	case IssueNonAlphabetic:
+		return "non-alphabetic"
+	case IssueEncoding:
+		return "encoding"
+	case IssueShort:
+		return "short"
+	}
+	return "IssueKind(" + fmt.Sprint(int(k)) + ")"
+}
+
+// Issue is a problem with a line of a word list.
+type Issue struct {
+	// Line is the 1-based line number.
+	Line int
+	// Word is the line, less any line ending.
+	Word string
+	Kind IssueKind
+	// Detail describes the problem, such as which line Word duplicates.
+	Detail string
+}
+
+// String returns a description of is, such as
+// `line 9: duplicate "Smith": same as line 2`.
+func (is Issue) String() string {
+	return fmt.Sprintf("line %d: %s %q: %s", is.Line, is.Kind, is.Word,
+		is.Detail)
+}
+
+// Report is the result of ValidateWordlist.
+type Report struct {
+	// Lines is the number of lines read.
+	Lines int
+	// Issues are the problems found, in line order.  A line can have
+	// more than one.
+	Issues []Issue
+}
+
+// OK returns true if r has no issues.
+func (r Report) OK() bool {
+	return len(r.Issues) == 0
+}
+
+// ValidateWordlist reads a word list, one word per line, from r and
+// reports the lines that would pollute a MetaphMap made from it:
+// duplicates, non-alphabetic words, encoding problems and suspiciously
+// short words.  Blank lines are not reported.  The error is from reading
+// r.
+func ValidateWordlist(r io.Reader) (rep Report, err error) {
+	seen := make(map[string]int)
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<20)
+	for sc.Scan() {
+		rep.Lines++
+		word := strings.TrimSuffix(sc.Text(), "\r")
+		issue := func(kind IssueKind, format string, a ...any) {
+			rep.Issues = append(rep.Issues, Issue{rep.Lines, word, kind,
+				fmt.Sprintf(format, a...)})
+		}
+		if len(strings.TrimSpace(word)) == 0 {
+			continue
+		}
+		if line, ok := seen[word]; ok {
+			issue(IssueDuplicate, "same as line %d", line)
+		} else {
+			seen[word] = rep.Lines
+		}
+		switch {
+		case !utf8.ValidString(word):
+			issue(IssueEncoding, "invalid UTF-8")
+		case strings.ContainsAny(word, "\x00\uFEFF\uFFFD"):
+			issue(IssueEncoding, "NUL, byte order mark or replacement character")
+		default:
+			if e := checkAlphabet(word, false, false); e != nil {
+				issue(IssueNonAlphabetic, "%v", e)
+			}
+		}
+		if utf8.RuneCountInString(word) == 1 && word != "a" && word != "I" {
+			issue(IssueShort, "one letter")
+		}
+	}
+	if err = sc.Err(); err != nil {
+		err = fmt.Errorf("trying to read word list: %v", err)
+	}
+	return
+}
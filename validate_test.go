@@ -0,0 +1,36 @@
+// validate_test.go - test validate.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateWordlist(t *testing.T) {
+	list := "Smith\r\nI\nx\n\nSmith\nR2D2\nbad\xff\n\uFEFFword\n"
+	rep, err := ValidateWordlist(strings.NewReader(list))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`line 3: short "x": one letter`,
+		`line 5: duplicate "Smith": same as line 1`,
+		`line 6: non-alphabetic "R2D2": metaphone: unsupported character '2' at byte 1 of "R2D2"`,
+		`line 7: encoding "bad\xff": invalid UTF-8`,
+		`line 8: encoding "\ufeffword": NUL, byte order mark or replacement character`,
+	}
+	if rep.Lines != 8 || len(rep.Issues) != len(want) {
+		t.Fatalf("got %d lines, issues: %v;  want 8 lines, %d issues",
+			rep.Lines, rep.Issues, len(want))
+	}
+	for i, is := range rep.Issues {
+		if got := is.String(); got != want[i] {
+			t.Errorf("got: %s;  want: %s", got, want[i])
+		}
+	}
+	if rep.OK() {
+		t.Errorf("OK got true")
+	}
+}
@@ -0,0 +1,90 @@
+// verify.go - check DoubleMetaphone against reference output.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// Mismatch is a word whose codes differ from those of a reference.
+type Mismatch struct {
+	// Line is the 1-based line number of Word.
+	Line int
+	Word string
+	// Got and Want are lines in the reference format for the codes
+	// DoubleMetaphone made and those the reference gives.
+	Got, Want string
+}
+
+// VerifyAgainstReference reads a word list, one word per line, from words
+// and the reference output for it from expected, and returns the words
+// whose DoubleMetaphone codes of at most maxLen characters differ from
+// the reference.  Reference lines are in the format of the package's test
+// data, which came from the original dmetaph.cpp:
+//
+//	'AXN' 'AKN' Aachen
+//
+// Line n of expected is for line n of words; blank words are skipped but
+// still take a line.  An empty result certifies that this build encodes
+// the corpus as the reference does.  An error is returned if either
+// reader fails, or, wrapping ErrDictionaryFormat, if expected has fewer
+// lines than words.
+func VerifyAgainstReference(words, expected io.Reader,
+	maxLen int) (mismatches []Mismatch, err error) {
+	ws := bufio.NewScanner(words)
+	es := bufio.NewScanner(expected)
+	line := 0
+	for ws.Scan() {
+		line++
+		if !es.Scan() {
+			if err = es.Err(); err == nil {
+				err = fmt.Errorf("%w: reference ends at line %d",
+					ErrDictionaryFormat, line)
+			}
+			return
+		}
+		word, want := ws.Text(), es.Text()
+		if len(word) == 0 {
+			continue
+		}
+		m, m2 := DoubleMetaphone(word, maxLen)
+		if got := referenceLine(word, m, m2); got != want {
+			mismatches = append(mismatches, Mismatch{line, word, got, want})
+		}
+	}
+	if err = ws.Err(); err != nil {
+		err = fmt.Errorf("trying to read words: %v", err)
+	} else if err = es.Err(); err != nil {
+		err = fmt.Errorf("trying to read reference: %v", err)
+	}
+	return
+}
+
+// referenceLine returns codes m and m2 for word in the reference format.
+func referenceLine(word, m, m2 string) string {
+	return fmt.Sprintf("'%s' '%s' %s", m, m2, word)
+}
+
+// WriteReference reads a word list, one word per line, from words and
+// writes the DoubleMetaphone codes of each word, of at most maxLen
+// characters, to w in the reference format read by
+// VerifyAgainstReference, one line per line of words.  Blank words get
+// blank lines.  It makes golden files for vocabularies of one's own.
+func WriteReference(w io.Writer, words io.Reader, maxLen int) error {
+	bw := bufio.NewWriter(w)
+	sc := bufio.NewScanner(words)
+	for sc.Scan() {
+		if word := sc.Text(); len(word) > 0 {
+			m, m2 := DoubleMetaphone(word, maxLen)
+			bw.WriteString(referenceLine(word, m, m2))
+		}
+		bw.WriteByte('\n')
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("trying to read words: %v", err)
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,61 @@
+// verify_test.go - test verify.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"compress/gzip"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestVerifyAgainstReference(t *testing.T) {
+	open := func(name string) *gzip.Reader {
+		fp, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { fp.Close() })
+		r, err := gzip.NewReader(fp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	mismatches, err := VerifyAgainstReference(open("testInputData.txt.gz"),
+		open("testWantData.txt.gz"), 6)
+	if err != nil || len(mismatches) != 0 {
+		t.Fatalf("got %d mismatches, %v;  want none", len(mismatches), err)
+	}
+	words := "Aachen\n\nSmith\n"
+	want := "'AXN' 'AKN' Aachen\n\n'SMT' 'XMT' Smith\n"
+	mismatches, err = VerifyAgainstReference(strings.NewReader(words),
+		strings.NewReader(want), 6)
+	if err != nil || len(mismatches) != 1 || mismatches[0].Line != 3 ||
+		mismatches[0].Got != "'SM0' 'XMT' Smith" {
+		t.Errorf("got: %+v, %v", mismatches, err)
+	}
+	_, err = VerifyAgainstReference(strings.NewReader(words),
+		strings.NewReader("'AXN' 'AKN' Aachen\n"), 6)
+	if !errors.Is(err, ErrDictionaryFormat) {
+		t.Errorf("got: %v;  want ErrDictionaryFormat", err)
+	}
+}
+
+func TestWriteReference(t *testing.T) {
+	var b strings.Builder
+	if err := WriteReference(&b, strings.NewReader("Aachen\n\nSmith"), 6); err != nil {
+		t.Fatal(err)
+	}
+	want := "'AXN' 'AKN' Aachen\n\n'SM0' 'XMT' Smith\n"
+	if got := b.String(); got != want {
+		t.Errorf("got: %q;  want: %q", got, want)
+	}
+	mismatches, err := VerifyAgainstReference(strings.NewReader("Aachen\n\nSmith"),
+		strings.NewReader(want), 6)
+	if err != nil || len(mismatches) != 0 {
+		t.Errorf("got: %+v, %v;  want none", mismatches, err)
+	}
+}
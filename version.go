@@ -0,0 +1,47 @@
+// version.go - select a version of the DoubleMetaphone rules.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import "strconv"
+
+// AlgorithmVersion identifies a version of the rules used to encode words.
+// Each change to how words are encoded, such as a fix for non-ASCII input
+// or a rule correction, is made under a new version, and an Encoder uses
+// the version it is given, so codes stored with one version keep their
+// meaning when this package is updated.  DoubleMetaphone always uses
+// Version1.
+type AlgorithmVersion int
+
+const (
+	// Version1 is the reference Double Metaphone algorithm of dmetaph.cpp,
+	// which DoubleMetaphone implements.
+	Version1 AlgorithmVersion = iota + 1
+	// Version2 measures words in characters instead of bytes, so rules
+	// that look at the end of a word, such as those for the silent final
+	// letters of French names, also work for words with non-ASCII
+	// letters, such as "Dübois" and "Théroux".
+	Version2
+	// Version3 also puts words in Unicode Normalization Form C before
+	// encoding them, and removes combining marks that do not combine with
+	// the letter before them, so a word typed with decomposed characters,
+	// such as "N" followed by a combining tilde, is encoded as the word
+	// with precomposed characters, such as "Ñ", is.
+	Version3
+
+	// LatestVersion is the newest AlgorithmVersion.
+	LatestVersion = Version3
+)
+
+// String returns the name of v, such as "Version1".
+func (v AlgorithmVersion) String() string {
+	switch v {
+	case Version1:
+		return "Version1"
+	case Version2:
+		return "Version2"
+	case Version3:
+		return "Version3"
+	}
+	return "AlgorithmVersion(" + strconv.Itoa(int(v)) + ")"
+}
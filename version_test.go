@@ -0,0 +1,64 @@
+// version_test.go - test version.go.
+// This file is public domain.
+
+package metaphone
+
+import "testing"
+
+func TestAlgorithmVersion(t *testing.T) {
+	tests := []struct {
+		word, ascii string
+	}{
+		{"Dübois", "Dubois"},
+		{"Théroux", "Theroux"},
+	}
+	for _, tt := range tests {
+		want, want2 := DoubleMetaphone(tt.ascii, 4)
+		v2 := &Encoder{Version: Version2}
+		if m, m2 := v2.Encode(tt.word); m != want || m2 != want2 {
+			t.Errorf("Version2 %s got: %s, %s;  want: %s, %s",
+				tt.word, m, m2, want, want2)
+		}
+		m, m2 := (&Encoder{}).Encode(tt.word)
+		if v1, v12 := DoubleMetaphone(tt.word, 4); m != v1 || m2 != v12 {
+			t.Errorf("zero Version %s got: %s, %s;  want: %s, %s",
+				tt.word, m, m2, v1, v12)
+		}
+		if m == want && m2 == want2 {
+			t.Errorf("Version1 %s unexpectedly matches %s", tt.word, tt.ascii)
+		}
+	}
+	if got := Version3.String(); got != "Version3" {
+		t.Errorf("got: %s;  want: Version3", got)
+	}
+	if got := Version2.String(); got != "Version2" {
+		t.Errorf("got: %s;  want: Version2", got)
+	}
+}
+
+func TestVersion3(t *testing.T) {
+	v3 := &Encoder{Version: Version3}
+	tests := []struct{ decomposed, composed string }{
+		{"Franc\u0327ois", "François"},
+		{"Nun\u0303ez", "Nuñez"},
+		{"The\u0301roux", "Théroux"},
+		{"Sm\u0338ith", "Smith"},
+	}
+	var res Result
+	for _, tt := range tests {
+		want, want2 := v3.Encode(tt.composed)
+		if m, m2 := v3.Encode(tt.decomposed); m != want || m2 != want2 {
+			t.Errorf("%q got: %s, %s;  want: %s, %s", tt.decomposed, m, m2,
+				want, want2)
+		}
+		v3.EncodeInto(tt.decomposed, &res)
+		if string(res.Metaph) != want || string(res.Metaph2) != want2 {
+			t.Errorf("EncodeInto %q got: %s, %s;  want: %s, %s", tt.decomposed,
+				res.Metaph, res.Metaph2, want, want2)
+		}
+	}
+	m, _ := (&Encoder{Version: Version2}).Encode("Franc\u0327ois")
+	if want, _ := v3.Encode("François"); m == want {
+		t.Errorf("Version2 unexpectedly composes %q", "Franc\u0327ois")
+	}
+}
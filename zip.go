@@ -0,0 +1,97 @@
+// zip.go - read word lists from zip archives.
+// Created 2026-10-16 and placed in the public domain.
+
+package metaphone
+
+import (
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// readZipWordlist returns the lines of the word list files in zip archive
+// zr, named name, in archive order.  Only members whose path or base name
+// matches one of patterns, as by path.Match, are read, or all members if
+// there are no patterns.  Members with names ending with ".gz" are
+// gunzipped.  Members are read in the character encoding and checked per
+// opts.  It is an error if no member is read.
+func readZipWordlist(zr *zip.Reader, name string, patterns []string,
+	opts Options) (lines []string, err error) {
+	read := 0
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		var ok bool
+		if ok, err = matchMember(f.Name, patterns); err != nil {
+			err = fmt.Errorf("zip file %s: %v", name, err)
+			return
+		}
+		if !ok {
+			continue
+		}
+		var member []string
+		if member, err = readZipMember(f, opts); err != nil {
+			err = fmt.Errorf("zip file %s: %w", name, err)
+			return
+		}
+		lines = append(lines, member...)
+		read++
+	}
+	if read == 0 {
+		err = fmt.Errorf("zip file %s: no word list file matches %q",
+			name, patterns)
+	}
+	return
+}
+
+// matchMember returns true if there are no patterns or if name or its base
+// name matches one of patterns.
+func matchMember(name string, patterns []string) (ok bool, err error) {
+	if len(patterns) == 0 {
+		return true, nil
+	}
+	for _, pattern := range patterns {
+		for _, s := range []string{name, path.Base(name)} {
+			if ok, err = path.Match(pattern, s); ok || err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// readZipMember returns the words of zip archive member f, in the
+// character encoding and checked per opts.
+func readZipMember(f *zip.File, opts Options) (lines []string, err error) {
+	var rc io.ReadCloser
+	if rc, err = f.Open(); err != nil {
+		err = fmt.Errorf("trying to open member %s: %v", f.Name, err)
+		return
+	}
+	defer rc.Close()
+	var r io.Reader = rc
+	if strings.HasSuffix(f.Name, ".gz") {
+		if r, err = gzip.NewReader(r); err != nil {
+			err = fmt.Errorf(
+				"trying to make a gzip reader for member %s: %v", f.Name, err)
+			return
+		}
+	}
+	var b []byte
+	if b, err = io.ReadAll(r); err != nil {
+		err = fmt.Errorf("trying to read member %s: %v", f.Name, err)
+		return
+	}
+	var text string
+	var line int
+	if text, line, err = decodeCharset(b, opts.Charset); err != nil {
+		err = fmt.Errorf("%w: trying to decode member %s: %v",
+			ErrDictionaryFormat, f.Name, err)
+		return
+	}
+	return splitWordlist(text, f.Name, line, opts)
+}
@@ -0,0 +1,72 @@
+// zip_test.go - test zip.go.
+// This file is public domain.
+
+package metaphone
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// writeZip writes a zip archive with members, alternating names and
+// contents, to a temporary file and returns its name.
+func writeZip(t *testing.T, members ...string) string {
+	name := filepath.Join(t.TempDir(), "words.zip")
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for i := 0; i < len(members); i += 2 {
+		w, err := zw.Create(members[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(members[i+1]))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+func TestZip(t *testing.T) {
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write([]byte("Smyth\n"))
+	zw.Close()
+	name := writeZip(t, "en/words.txt", "Smith\nSchmidt\n",
+		"en/more.txt.gz", gz.String(), "de/words.txt", "Schmitt\n",
+		"README", "Smite\n")
+
+	metaph, err := NewMetaphMapFromFile(name, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := metaph.MatchWord("Smith"); len(got) != 5 {
+		t.Errorf("all members got: %v", got)
+	}
+
+	metaph, err = NewBuilder().FromZip(name, "*.txt", "en/*.gz").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := metaph.MatchWord("Smith")
+	sort.Strings(got)
+	if want := "[Schmidt Schmitt Smith Smyth]"; fmt.Sprint(got) != want {
+		t.Errorf("got: %v;  want: %s", got, want)
+	}
+
+	if _, err = NewBuilder().FromZip(name, "*.dic").Build(); err == nil {
+		t.Errorf("no matching member got nil error")
+	}
+	if _, err = NewBuilder().FromZip(name, "[").Build(); err == nil {
+		t.Errorf("bad pattern got nil error")
+	}
+}